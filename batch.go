@@ -0,0 +1,76 @@
+package rank
+
+import "time"
+
+// ScoreUpdate a single score update in a batch write
+type ScoreUpdate[D any] struct {
+	// Member member identifier
+	Member string
+	// Score new score
+	Score int64
+	// Data additional data
+	Data D
+	// TTL time after which the member expires unless updated again, 0 or less uses the MemberTTL of the configuration
+	TTL time.Duration
+}
+
+// AddMany applies a batch of score updates under a single lock acquisition, in order, following the update policy.
+// The results are in the same order as the updates. When withRanks is true, every result reports the ranks
+// and overtaken members right before and after its own update
+func (lb *TypedLeaderboard[D]) AddMany(updates []ScoreUpdate[D], withRanks bool) []AddResult[D] {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	results := make([]AddResult[D], len(updates))
+	for i, update := range updates {
+		results[i] = lb.add(update.Member, update.Score, update.Data, update.TTL, withRanks)
+	}
+
+	return results
+}
+
+// RemoveMany removes a batch of members under a single lock acquisition, and returns the number of members removed.
+// Errors are reported like Remove, in which case no member is removed
+func (lb *TypedLeaderboard[D]) RemoveMany(members []string) (int, error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	// The members are removed at once, so that a single record is logged
+	elements := make([]*TypedElement[string, int64, TypedMemberData[D]], 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		element := lb.skipList.GetElementByMember(member)
+		if element != nil && !seen[member] {
+			seen[member] = true
+			elements = append(elements, element)
+		}
+	}
+
+	if err := lb.remove(elements); err != nil {
+		return 0, err
+	}
+
+	return len(elements), nil
+}
+
+// GetMembersAndRanks gets the data and rank of a batch of members under a single read lock, so that all ranks
+// come from the same consistent view. Members that don't exist are left out of the result
+func (lb *TypedLeaderboard[D]) GetMembersAndRanks(members []string) map[string]*TypedRankData[D] {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	result := make(map[string]*TypedRankData[D], len(members))
+	for _, member := range members {
+		element := lb.skipList.GetElementByMember(member)
+		if element == nil {
+			continue
+		}
+
+		result[member] = &TypedRankData[D]{
+			Rank:            lb.rank(element),
+			TypedMemberData: element.Data,
+		}
+	}
+
+	return result
+}
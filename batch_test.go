@@ -0,0 +1,132 @@
+package rank
+
+import (
+	"testing"
+)
+
+func TestLeaderboardAddMany(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "batch",
+		Name:         "Batch Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateIfHigher,
+	})
+
+	lb.Add("player1", 500, nil)
+
+	results := lb.AddMany([]ScoreUpdate[interface{}]{
+		{Member: "player1", Score: 100, Data: nil},
+		{Member: "player2", Score: 200, Data: "data2"},
+		{Member: "player3", Score: 300, Data: "data3"},
+		{Member: "player2", Score: 600, Data: "data2"},
+	}, true)
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+
+	// Test the rejected update
+	if results[0].Status != AddRejected || results[0].Err == nil {
+		t.Errorf("Expected player1 update to be rejected, got status %d", results[0].Status)
+	}
+
+	if results[0].Member != "player1" {
+		t.Errorf("Expected rejected result for player1, got %s", results[0].Member)
+	}
+
+	// Test the applied updates, ranks are computed right after each update
+	expectedRanks := []int64{0, 2, 2, 1}
+	for i := 1; i < len(results); i++ {
+		if results[i].Status != AddApplied || results[i].Err != nil {
+			t.Errorf("Expected update %d to be applied, got status %d: %v", i, results[i].Status, results[i].Err)
+		}
+		if results[i].Rank != expectedRanks[i] {
+			t.Errorf("Expected rank %d for update %d, got %d", expectedRanks[i], i, results[i].Rank)
+		}
+	}
+
+	// The second update of player2 reports its standing right after the first one
+	if results[3].PrevScore != 200 || results[3].PrevRank != 3 {
+		t.Errorf("Expected previous score 200 at rank 3, got %d at rank %d", results[3].PrevScore, results[3].PrevRank)
+	}
+
+	if lb.GetTotal() != 3 {
+		t.Errorf("Expected total 3, got %d", lb.GetTotal())
+	}
+
+	memberData, _ := lb.GetMember("player2")
+	if memberData.Score != 600 {
+		t.Errorf("Expected score 600 for player2, got %d", memberData.Score)
+	}
+
+	// Test without ranks
+	results = lb.AddMany([]ScoreUpdate[interface{}]{
+		{Member: "player4", Score: 50},
+	}, false)
+
+	if results[0].Status != AddApplied || results[0].Rank != 0 {
+		t.Errorf("Expected applied update without rank, got status %d and rank %d", results[0].Status, results[0].Rank)
+	}
+}
+
+func TestLeaderboardRemoveMany(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "batch_remove",
+		Name:         "Batch Remove Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	lb.Add("player1", 100, nil)
+	lb.Add("player2", 200, nil)
+	lb.Add("player3", 300, nil)
+
+	removed, err := lb.RemoveMany([]string{"player1", "player3", "missing", "player1"})
+	if err != nil || removed != 2 {
+		t.Errorf("Expected 2 members removed, got %d %v", removed, err)
+	}
+
+	if lb.GetTotal() != 1 {
+		t.Errorf("Expected total 1, got %d", lb.GetTotal())
+	}
+
+	rank, _ := lb.GetRank("player2")
+	if rank != 1 {
+		t.Errorf("Expected rank 1 for player2, got %d", rank)
+	}
+}
+
+func TestLeaderboardGetMembersAndRanks(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "batch_get",
+		Name:         "Batch Get Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	lb.Add("player1", 100, "data1")
+	lb.Add("player2", 200, "data2")
+	lb.Add("player3", 300, "data3")
+
+	result := lb.GetMembersAndRanks([]string{"player1", "player3", "missing"})
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 members, got %d", len(result))
+	}
+
+	if _, ok := result["missing"]; ok {
+		t.Error("Expected missing member to be left out")
+	}
+
+	if result["player1"].Rank != 3 || result["player1"].Data != "data1" {
+		t.Errorf("Expected player1 at rank 3 with data1, got rank %d with %v", result["player1"].Rank, result["player1"].Data)
+	}
+
+	if result["player3"].Rank != 1 || result["player3"].Score != 300 {
+		t.Errorf("Expected player3 at rank 1 with score 300, got rank %d with %d", result["player3"].Rank, result["player3"].Score)
+	}
+
+	// Test empty input
+	if result := lb.GetMembersAndRanks(nil); len(result) != 0 {
+		t.Errorf("Expected empty result, got %d members", len(result))
+	}
+}
@@ -66,6 +66,29 @@ func BenchmarkSkipListInsert(b *testing.B) {
 	}
 }
 
+// Benchmark: building a skip list from sorted elements, compared with inserting them one by one
+func BenchmarkSkipListFromSorted(b *testing.B) {
+	elements := make([]Element, 100000)
+	for i := range elements {
+		elements[i] = Element{Member: generateID(12), Score: int64(len(elements) - i)}
+	}
+
+	b.Run("FromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewSkipListFromSorted(SkipListConfig[string, int64, interface{}]{}, elements)
+		}
+	})
+
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sl := NewSkipList()
+			for _, element := range elements {
+				sl.Insert(element.Member, element.Score, element.Data)
+			}
+		}
+	})
+}
+
 // Benchmark: skip list lookup
 func BenchmarkSkipListGet(b *testing.B) {
 	benchmarks := []struct {
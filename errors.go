@@ -0,0 +1,96 @@
+package rank
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrMemberNotFound is returned when the member does not exist in the leaderboard
+	ErrMemberNotFound = errors.New("member does not exist")
+	// ErrScoreRejected is returned when the new score is rejected by the update policy,
+	// use errors.As with a *ScoreRejectedError to get the details
+	ErrScoreRejected = errors.New("score rejected by update policy")
+	// ErrBelowCutoff is returned when a new member would rank below the last member of a full leaderboard,
+	// use errors.As with a *BelowCutoffError to get the details
+	ErrBelowCutoff = errors.New("score is below the leaderboard cutoff")
+	// ErrScoreOverflow is returned when an increment would overflow the score
+	ErrScoreOverflow = errors.New("score overflow")
+	// ErrDataType is returned when the additional data is not of the requested type
+	ErrDataType = errors.New("data type error")
+	// ErrFrozen is returned when writing to a frozen leaderboard
+	ErrFrozen = errors.New("leaderboard is frozen")
+	// ErrInvalidTier is returned when a reward tier has an invalid rank range or percentage
+	ErrInvalidTier = errors.New("invalid tier")
+	// ErrInvalidSnapshot is returned when a snapshot is corrupted, truncated or of an unsupported version
+	ErrInvalidSnapshot = errors.New("invalid snapshot")
+	// ErrDuplicateMember is returned when a skip list is built from elements with a duplicated member
+	ErrDuplicateMember = errors.New("duplicate member")
+	// ErrNotSorted is returned when a skip list is built from elements that are not in rank order
+	ErrNotSorted = errors.New("elements are not sorted in rank order")
+	// ErrInvalidPercentile is returned when a percentile is not in (0, 100]
+	ErrInvalidPercentile = errors.New("percentile must be in (0, 100]")
+)
+
+// ScoreRejectedError details of a score rejected by the update policy, it matches ErrScoreRejected with errors.Is
+type ScoreRejectedError struct {
+	// OldScore existing score of the member
+	OldScore int64
+	// NewScore rejected score
+	NewScore int64
+	// Policy update policy that rejected the score
+	Policy UpdatePolicy
+	// higher whether the new score had to be higher than the existing score
+	higher bool
+}
+
+// Error implements the error interface
+func (e *ScoreRejectedError) Error() string {
+	if e.higher {
+		return fmt.Sprintf("new score %d is not higher than existing score %d", e.NewScore, e.OldScore)
+	}
+	return fmt.Sprintf("new score %d is not lower than existing score %d", e.NewScore, e.OldScore)
+}
+
+// Unwrap returns ErrScoreRejected
+func (e *ScoreRejectedError) Unwrap() error {
+	return ErrScoreRejected
+}
+
+// BelowCutoffError details of a new member rejected by a full leaderboard, it matches ErrBelowCutoff with errors.Is
+type BelowCutoffError struct {
+	// Score rejected score
+	Score int64
+	// Cutoff score of the last member of the leaderboard
+	Cutoff int64
+	// MaxMembers capacity of the leaderboard
+	MaxMembers int
+}
+
+// Error implements the error interface
+func (e *BelowCutoffError) Error() string {
+	return fmt.Sprintf("score %d does not make the top %d, cutoff score is %d", e.Score, e.MaxMembers, e.Cutoff)
+}
+
+// Unwrap returns ErrBelowCutoff
+func (e *BelowCutoffError) Unwrap() error {
+	return ErrBelowCutoff
+}
+
+// GetDataAs gets the additional data of a member in an untyped leaderboard as type T,
+// ErrDataType is returned when the data is not of type T
+func GetDataAs[T any](lb *Leaderboard, member string) (T, error) {
+	var zero T
+
+	memberData, err := lb.GetMember(member)
+	if err != nil {
+		return zero, err
+	}
+
+	data, ok := memberData.Data.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: member %s has data of type %T", ErrDataType, member, memberData.Data)
+	}
+
+	return data, nil
+}
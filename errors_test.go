@@ -0,0 +1,89 @@
+package rank
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLeaderboardErrors(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "errors",
+		Name:         "Errors Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateIfHigher,
+	})
+
+	// Test member not found
+	if _, err := lb.GetRank("missing"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound from GetRank, got %v", err)
+	}
+
+	if _, err := lb.GetMember("missing"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound from GetMember, got %v", err)
+	}
+
+	if _, err := lb.GetMemberAndRank("missing"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound from GetMemberAndRank, got %v", err)
+	}
+
+	if _, err := lb.GetAroundMember("missing", 1); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound from GetAroundMember, got %v", err)
+	}
+
+	// Test score rejected
+	lb.Add("player1", 100, nil)
+
+	_, err := lb.Add("player1", 50, nil)
+	if !errors.Is(err, ErrScoreRejected) {
+		t.Fatalf("Expected ErrScoreRejected, got %v", err)
+	}
+
+	var rejectedErr *ScoreRejectedError
+	if !errors.As(err, &rejectedErr) {
+		t.Fatalf("Expected *ScoreRejectedError, got %T", err)
+	}
+
+	if rejectedErr.OldScore != 100 || rejectedErr.NewScore != 50 || rejectedErr.Policy != UpdateIfHigher {
+		t.Errorf("Expected old score 100, new score 50 and UpdateIfHigher, got %d, %d and %d",
+			rejectedErr.OldScore, rejectedErr.NewScore, rejectedErr.Policy)
+	}
+
+	if err.Error() != "new score 50 is not higher than existing score 100" {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+
+	// Test score overflow
+	lb.Add("player2", math.MaxInt64, nil)
+	if _, err := lb.Incr("player2", 1, nil); !errors.Is(err, ErrScoreOverflow) {
+		t.Errorf("Expected ErrScoreOverflow, got %v", err)
+	}
+}
+
+func TestGetDataAs(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "data_as",
+		Name:         "Data As Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	lb.Add("player1", 100, "data1")
+
+	data, err := GetDataAs[string](lb, "player1")
+	if err != nil {
+		t.Fatalf("Failed to get data: %v", err)
+	}
+
+	if data != "data1" {
+		t.Errorf("Expected data 'data1', got %s", data)
+	}
+
+	if _, err := GetDataAs[int](lb, "player1"); !errors.Is(err, ErrDataType) {
+		t.Errorf("Expected ErrDataType, got %v", err)
+	}
+
+	if _, err := GetDataAs[string](lb, "missing"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound, got %v", err)
+	}
+}
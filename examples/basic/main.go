@@ -16,11 +16,6 @@ func main() {
 		UpdatePolicy: rank.UpdateAlways,
 	}
 
-	leaderboard := rank.NewLeaderboard(config)
-
-	// Add some player data
-	fmt.Println("Adding player data...")
-
 	// You can add any type of additional data
 	type PlayerInfo struct {
 		Nickname string
@@ -28,6 +23,12 @@ func main() {
 		Avatar   string
 	}
 
+	// The data type is checked at compile time, no type assertion is needed when reading it back
+	leaderboard := rank.NewTypedLeaderboard[PlayerInfo](config)
+
+	// Add some player data
+	fmt.Println("Adding player data...")
+
 	// Add player 1
 	player1 := PlayerInfo{
 		Nickname: "Super Player",
@@ -79,7 +80,7 @@ func main() {
 	fmt.Println("\nGetting top 3 players:")
 	topThree, _ := leaderboard.GetRankList(1, 3)
 	for _, item := range topThree {
-		playerInfo := item.Data.(PlayerInfo)
+		playerInfo := item.Data
 		fmt.Printf("Rank: %d, Member: %s, Score: %d, Nickname: %s, Level: %d\n",
 			item.Rank, item.Member, item.Score, playerInfo.Nickname, playerInfo.Level)
 	}
@@ -93,7 +94,7 @@ func main() {
 	fmt.Println("\nUpdated top 3 players:")
 	topThree, _ = leaderboard.GetRankList(1, 3)
 	for _, item := range topThree {
-		playerInfo := item.Data.(PlayerInfo)
+		playerInfo := item.Data
 		fmt.Printf("Rank: %d, Member: %s, Score: %d, Nickname: %s, Level: %d\n",
 			item.Rank, item.Member, item.Score, playerInfo.Nickname, playerInfo.Level)
 	}
@@ -101,7 +102,7 @@ func main() {
 	// Get specific player rank
 	fmt.Println("\nGetting player 4's rank and data:")
 	player4Data, _ := leaderboard.GetMemberAndRank("player4")
-	playerInfo4 := player4Data.Data.(PlayerInfo)
+	playerInfo4 := player4Data.Data
 	fmt.Printf("Player 4, Rank: %d, Score: %d, Nickname: %s, Level: %d\n",
 		player4Data.Rank, player4Data.Score, playerInfo4.Nickname, playerInfo4.Level)
 
@@ -109,7 +110,7 @@ func main() {
 	fmt.Println("\nGetting rankings around player 4 (1 above and 1 below):")
 	aroundPlayer4, _ := leaderboard.GetAroundMember("player4", 1)
 	for _, item := range aroundPlayer4 {
-		playerInfo := item.Data.(PlayerInfo)
+		playerInfo := item.Data
 		fmt.Printf("Rank: %d, Member: %s, Score: %d, Nickname: %s, Level: %d\n",
 			item.Rank, item.Member, item.Score, playerInfo.Nickname, playerInfo.Level)
 	}
@@ -162,9 +163,9 @@ func main() {
 
 	// Try to add a lower score, should fail
 	fmt.Println("\nTrying to add a lower time (should fail):")
-	_, err := raceLeaderboard.Add("racer2", 100, "Racer 2 new record: 100 seconds")
+	current, err := raceLeaderboard.Add("racer2", 100, "Racer 2 new record: 100 seconds")
 	if err != nil {
-		fmt.Printf("Expected error: %v\n", err)
+		fmt.Printf("Expected error: %v, current Rank: %d, current Time: %d seconds\n", err, current.Rank, current.Score)
 	} else {
 		fmt.Println("Racer 2 updated successfully, but this is not expected!")
 	}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -79,7 +80,7 @@ func handleAddScore(w http.ResponseWriter, r *http.Request) {
 	// Add to leaderboard
 	rankData, err := gameLeaderboard.Add(playerScore.Member, playerScore.Score, playerScore.Data)
 	if err != nil {
-		sendResponse(w, false, fmt.Sprintf("Failed to add score: %v", err), nil)
+		sendError(w, err, "Failed to add score")
 		return
 	}
 
@@ -107,7 +108,7 @@ func handleGetRank(w http.ResponseWriter, r *http.Request) {
 	// Get rank and data
 	rankData, err := gameLeaderboard.GetMemberAndRank(member)
 	if err != nil {
-		sendResponse(w, false, fmt.Sprintf("Failed to get rank: %v", err), nil)
+		sendError(w, err, "Failed to get rank")
 		return
 	}
 
@@ -149,7 +150,7 @@ func handleGetTopList(w http.ResponseWriter, r *http.Request) {
 	// Get leaderboard
 	rankList, err := gameLeaderboard.GetRankList(start, end)
 	if err != nil {
-		sendResponse(w, false, fmt.Sprintf("Failed to get leaderboard: %v", err), nil)
+		sendError(w, err, "Failed to get leaderboard")
 		return
 	}
 
@@ -194,7 +195,7 @@ func handleGetAroundMember(w http.ResponseWriter, r *http.Request) {
 	// Get ranks around member
 	rankList, err := gameLeaderboard.GetAroundMember(member, count)
 	if err != nil {
-		sendResponse(w, false, fmt.Sprintf("Failed to get ranks around member: %v", err), nil)
+		sendError(w, err, "Failed to get ranks around member")
 		return
 	}
 
@@ -233,7 +234,11 @@ func handleRemoveMember(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Remove member
-	removed := gameLeaderboard.Remove(playerScore.Member)
+	removed, err := gameLeaderboard.Remove(playerScore.Member)
+	if err != nil {
+		sendError(w, err, "Failed to remove member")
+		return
+	}
 	if !removed {
 		sendResponse(w, false, "Failed to remove member, may not exist", nil)
 		return
@@ -268,6 +273,29 @@ func sendResponse(w http.ResponseWriter, success bool, message string, data inte
 	json.NewEncoder(w).Encode(response)
 }
 
+// sendError sends a failed JSON response, with a status code mapped from the leaderboard error
+func sendError(w http.ResponseWriter, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, rank.ErrMemberNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, rank.ErrScoreRejected), errors.Is(err, rank.ErrBelowCutoff), errors.Is(err, rank.ErrFrozen):
+		status = http.StatusConflict
+	case errors.Is(err, rank.ErrScoreOverflow):
+		status = http.StatusBadRequest
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	response := RankResponse{
+		Success: false,
+		Message: fmt.Sprintf("%s: %v", message, err),
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
+
 func main() {
 	// Set up routes
 	http.HandleFunc("/api/score/add", handleAddScore)
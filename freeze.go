@@ -0,0 +1,27 @@
+package rank
+
+// Freeze locks the leaderboard against writes, such as at the end of a season before paying out rewards.
+// While frozen, adds, removals and resets fail with ErrFrozen, and members don't expire
+func (lb *TypedLeaderboard[D]) Freeze() {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	lb.frozen = true
+}
+
+// Unfreeze accepts writes again, members that expired while the leaderboard was frozen are removed.
+// Point-in-time views stay frozen
+func (lb *TypedLeaderboard[D]) Unfreeze() {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.frozen = lb.view
+}
+
+// IsFrozen reports whether the leaderboard is frozen
+func (lb *TypedLeaderboard[D]) IsFrozen() bool {
+	lb.mutex.RLock()
+	defer lb.mutex.RUnlock()
+
+	return lb.frozen
+}
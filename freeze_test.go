@@ -0,0 +1,106 @@
+package rank
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardFreeze(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "freeze",
+		Name:         "Freeze Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		MemberTTL:    time.Minute,
+	})
+	now := time.Unix(1700000000, 0)
+	lb.now = func() time.Time {
+		return now
+	}
+
+	lb.Add("a", 100, nil)
+	lb.Add("b", 90, nil)
+	lb.Add("c", 80, nil)
+
+	var buf bytes.Buffer
+	lb.SaveSnapshot(&buf)
+
+	lb.Freeze()
+	if !lb.IsFrozen() {
+		t.Error("Expected the leaderboard to be frozen")
+	}
+
+	// Writes fail with the current standing
+	result, err := lb.Add("b", 200, nil)
+	if !errors.Is(err, ErrFrozen) || result.Status != AddFailed || result.Score != 90 || result.Rank != 2 {
+		t.Errorf("Expected the write to fail with b at rank 2, got %+v %v", result, err)
+	}
+
+	result, err = lb.Incr("d", 10, nil)
+	if !errors.Is(err, ErrFrozen) || !result.IsNew {
+		t.Errorf("Expected the write of a new member to fail, got %+v %v", result, err)
+	}
+
+	results := lb.AddMany([]ScoreUpdate[interface{}]{{Member: "e", Score: 1}}, false)
+	if !errors.Is(results[0].Err, ErrFrozen) {
+		t.Errorf("Expected the batch write to fail, got %v", results[0].Err)
+	}
+
+	// Removals remove nothing
+	if removed, err := lb.Remove("a"); removed || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected a not to be removed with ErrFrozen, got %v %v", removed, err)
+	}
+	if removed, err := lb.Remove("missing"); removed || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected ErrFrozen for a missing member, got %v %v", removed, err)
+	}
+	if n, err := lb.RemoveMany([]string{"a", "b"}); n != 0 || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected nothing to be removed with ErrFrozen, got %d %v", n, err)
+	}
+	if n, err := lb.RemoveRankRange(1, 3); n != 0 || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected nothing to be removed with ErrFrozen, got %d %v", n, err)
+	}
+	if n, err := lb.RemoveScoreRange(0, 100); n != 0 || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected nothing to be removed with ErrFrozen, got %d %v", n, err)
+	}
+	if popped, err := lb.PopTop(1); len(popped) != 0 || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected nothing to be popped with ErrFrozen, got %v %v", popped, err)
+	}
+	if popped, err := lb.PopBottom(1); len(popped) != 0 || !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected nothing to be popped with ErrFrozen, got %v %v", popped, err)
+	}
+	if err := lb.Reset(); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected ErrFrozen, got %v", err)
+	}
+	if err := lb.LoadSnapshot(&buf); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected ErrFrozen, got %v", err)
+	}
+
+	// Members don't expire while frozen
+	now = now.Add(2 * time.Minute)
+	if total := lb.GetTotal(); total != 3 {
+		t.Errorf("Expected 3 members, got %d", total)
+	}
+	if rankData, err := lb.GetMemberAndRank("c"); err != nil || rankData.Rank != 3 {
+		t.Errorf("Expected c at rank 3, got %v %v", rankData, err)
+	}
+
+	// Expired members are removed once the leaderboard is unfrozen
+	lb.Unfreeze()
+	if lb.IsFrozen() {
+		t.Error("Expected the leaderboard not to be frozen")
+	}
+	if total := lb.GetTotal(); total != 0 {
+		t.Errorf("Expected the expired members to be removed, got %d", total)
+	}
+	if result, err := lb.Add("b", 200, nil); err != nil || !result.Applied() {
+		t.Errorf("Expected the write to succeed, got %+v %v", result, err)
+	}
+
+	// Point-in-time views are frozen
+	view := lb.Snapshot().(*Leaderboard)
+	if _, err := view.Add("x", 1, nil); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected the view to be frozen, got %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package rank
+
+import (
+	"iter"
+)
+
+// All returns an iterator over all elements in rank order, yielding each element with its rank
+func (sl *TypedSkipList[K, S, D]) All() iter.Seq2[int64, *TypedElement[K, S, D]] {
+	return sl.FromRank(1)
+}
+
+// Backward returns an iterator over all elements in reverse rank order, starting from the last rank
+func (sl *TypedSkipList[K, S, D]) Backward() iter.Seq2[int64, *TypedElement[K, S, D]] {
+	return func(yield func(int64, *TypedElement[K, S, D]) bool) {
+		rank := int64(sl.length)
+		for x := sl.tail; x != nil; x = sl.links(x).backward {
+			if !yield(rank, &x.element) {
+				return
+			}
+			rank--
+		}
+	}
+}
+
+// FromRank returns an iterator over the elements in rank order, starting from the specified rank
+func (sl *TypedSkipList[K, S, D]) FromRank(rank int64) iter.Seq2[int64, *TypedElement[K, S, D]] {
+	return func(yield func(int64, *TypedElement[K, S, D]) bool) {
+		if rank < 1 {
+			rank = 1
+		}
+
+		// Seek to the start rank once, then walk the bottom level
+		for x := sl.getNodeByRank(rank); x != nil; x = sl.next(x, 0).forward {
+			if !yield(rank, &x.element) {
+				return
+			}
+			rank++
+		}
+	}
+}
+
+// ScoreBetween returns an iterator over the elements within a specified score range (inclusive), in rank order
+func (sl *TypedSkipList[K, S, D]) ScoreBetween(min, max S) iter.Seq2[int64, *TypedElement[K, S, D]] {
+	return func(yield func(int64, *TypedElement[K, S, D]) bool) {
+		if min > max {
+			return
+		}
+
+		first, last := sl.scoreBounds(min, max)
+		x, rank := sl.seekScore(first)
+		for ; x != nil && sl.compareScore(x.element.Score, last) <= 0; x = sl.next(x, 0).forward {
+			if !yield(rank, &x.element) {
+				return
+			}
+			rank++
+		}
+	}
+}
+
+// All returns an iterator over all members in rank order.
+// The read lock is held for the whole iteration, so the loop body must not call other methods of the leaderboard.
+func (lb *TypedLeaderboard[D]) All() iter.Seq2[int64, *TypedRankData[D]] {
+	return lb.iterate(func(sl *TypedSkipList[string, int64, TypedMemberData[D]]) iter.Seq2[int64, *TypedElement[string, int64, TypedMemberData[D]]] {
+		return sl.All()
+	}, false)
+}
+
+// Backward returns an iterator over all members in reverse rank order.
+// The read lock is held for the whole iteration, so the loop body must not call other methods of the leaderboard.
+func (lb *TypedLeaderboard[D]) Backward() iter.Seq2[int64, *TypedRankData[D]] {
+	return lb.iterate(func(sl *TypedSkipList[string, int64, TypedMemberData[D]]) iter.Seq2[int64, *TypedElement[string, int64, TypedMemberData[D]]] {
+		return sl.Backward()
+	}, true)
+}
+
+// FromRank returns an iterator over the members in rank order, starting from the specified ordinal position.
+// The read lock is held for the whole iteration, so the loop body must not call other methods of the leaderboard.
+func (lb *TypedLeaderboard[D]) FromRank(rank int64) iter.Seq2[int64, *TypedRankData[D]] {
+	return lb.iterate(func(sl *TypedSkipList[string, int64, TypedMemberData[D]]) iter.Seq2[int64, *TypedElement[string, int64, TypedMemberData[D]]] {
+		return sl.FromRank(rank)
+	}, false)
+}
+
+// ScoreBetween returns an iterator over the members within a specified score range (inclusive), in rank order.
+// The read lock is held for the whole iteration, so the loop body must not call other methods of the leaderboard.
+func (lb *TypedLeaderboard[D]) ScoreBetween(min, max int64) iter.Seq2[int64, *TypedRankData[D]] {
+	return lb.iterate(func(sl *TypedSkipList[string, int64, TypedMemberData[D]]) iter.Seq2[int64, *TypedElement[string, int64, TypedMemberData[D]]] {
+		return sl.ScoreBetween(min, max)
+	}, false)
+}
+
+// iterate wraps a skip list iterator, holding the read lock from the first to the last yielded member, but not
+// while the loop body of a view runs, and converting ordinal positions into ranks of the ranking mode
+func (lb *TypedLeaderboard[D]) iterate(elements func(sl *TypedSkipList[string, int64, TypedMemberData[D]]) iter.Seq2[int64, *TypedElement[string, int64, TypedMemberData[D]]], backward bool) iter.Seq2[int64, *TypedRankData[D]] {
+	return func(yield func(int64, *TypedRankData[D]) bool) {
+		lb.lockRead()
+		locked := true
+		defer func() {
+			if locked {
+				lb.mutex.RUnlock()
+			}
+		}()
+
+		r := ranker[D]{lb: lb, backward: backward}
+		for position, element := range elements(lb.skipList) {
+			rank := r.next(position, element)
+			rankData := &TypedRankData[D]{Rank: rank, TypedMemberData: element.Data}
+
+			// A view doesn't change, so the lock it shares with its leaderboard is not held by the loop body
+			if lb.view {
+				lb.mutex.RUnlock()
+				locked = false
+			}
+			ok := yield(rank, rankData)
+			if lb.view {
+				lb.mutex.RLock()
+				locked = true
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package rank
+
+import (
+	"testing"
+)
+
+func TestSkipListIterators(t *testing.T) {
+	sl := NewSkipList()
+
+	// Insert some data, scores are arranged in descending order
+	for i := 1; i <= 10; i++ {
+		sl.Insert("key"+string(rune('0'+i)), int64(i*100), i)
+	}
+
+	// Test iterating over all elements
+	var expectedRank int64 = 1
+	for rank, element := range sl.All() {
+		if rank != expectedRank {
+			t.Errorf("Expected rank %d, got %d", expectedRank, rank)
+		}
+		if element.Score != int64((11-rank)*100) {
+			t.Errorf("Expected score %d at rank %d, got %d", (11-rank)*100, rank, element.Score)
+		}
+		expectedRank++
+	}
+
+	if expectedRank != 11 {
+		t.Errorf("Expected 10 elements, got %d", expectedRank-1)
+	}
+
+	// Test iterating backward
+	expectedRank = 10
+	for rank, element := range sl.Backward() {
+		if rank != expectedRank {
+			t.Errorf("Expected rank %d, got %d", expectedRank, rank)
+		}
+		if sl.GetRank(element.Member, element.Score) != rank {
+			t.Errorf("Expected element at rank %d, got rank %d", rank, sl.GetRank(element.Member, element.Score))
+		}
+		expectedRank--
+	}
+
+	if expectedRank != 0 {
+		t.Errorf("Expected 10 elements backward, got %d", 10-expectedRank)
+	}
+
+	// Test iterating from a rank and stopping early
+	var ranks []int64
+	for rank := range sl.FromRank(8) {
+		ranks = append(ranks, rank)
+	}
+
+	if len(ranks) != 3 || ranks[0] != 8 {
+		t.Errorf("Expected ranks 8 to 10, got %v", ranks)
+	}
+
+	count := 0
+	for range sl.FromRank(1) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("Expected to stop after 2 elements, got %d", count)
+	}
+
+	// Test iterating over a score range
+	ranks = nil
+	for rank, element := range sl.ScoreBetween(300, 600) {
+		if element.Score < 300 || element.Score > 600 {
+			t.Errorf("Expected score between 300 and 600, got %d", element.Score)
+		}
+		ranks = append(ranks, rank)
+	}
+
+	if len(ranks) != 4 || ranks[0] != 5 || ranks[3] != 8 {
+		t.Errorf("Expected ranks 5 to 8, got %v", ranks)
+	}
+
+	// Test the backward pointers after deletion
+	sl.Delete("key1", 100)
+	sl.Delete("key5", 500)
+
+	count = 0
+	for rank := range sl.Backward() {
+		if rank != int64(8-count) {
+			t.Errorf("Expected rank %d, got %d", 8-count, rank)
+		}
+		count++
+	}
+
+	if count != 8 {
+		t.Errorf("Expected 8 elements backward after deletion, got %d", count)
+	}
+}
+
+func TestLeaderboardIterators(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "iter",
+		Name:         "Iterator Test",
+		ScoreOrder:   false,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	for i := 1; i <= 5; i++ {
+		lb.Add("player"+string(rune('0'+i)), int64(i*10), nil)
+	}
+
+	// Test iterating in rank order, low scores first
+	var members []string
+	for rank, item := range lb.All() {
+		if item.Rank != rank {
+			t.Errorf("Expected item rank %d to match %d", item.Rank, rank)
+		}
+		members = append(members, item.Member)
+	}
+
+	if len(members) != 5 || members[0] != "player1" || members[4] != "player5" {
+		t.Errorf("Expected player1 to player5, got %v", members)
+	}
+
+	// Test iterating backward
+	members = nil
+	for _, item := range lb.Backward() {
+		members = append(members, item.Member)
+	}
+
+	if len(members) != 5 || members[0] != "player5" {
+		t.Errorf("Expected player5 first backward, got %v", members)
+	}
+
+	// Test iterating from a rank
+	members = nil
+	for _, item := range lb.FromRank(4) {
+		members = append(members, item.Member)
+	}
+
+	if len(members) != 2 || members[0] != "player4" {
+		t.Errorf("Expected player4 and player5, got %v", members)
+	}
+
+	// Test iterating over a score range in an ascending leaderboard
+	var ranks []int64
+	for rank, item := range lb.ScoreBetween(20, 40) {
+		if item.Score < 20 || item.Score > 40 {
+			t.Errorf("Expected score between 20 and 40, got %d", item.Score)
+		}
+		ranks = append(ranks, rank)
+	}
+
+	if len(ranks) != 3 || ranks[0] != 2 || ranks[2] != 4 {
+		t.Errorf("Expected ranks 2 to 4, got %v", ranks)
+	}
+
+	// The lock must be released after breaking out of the loop
+	for range lb.All() {
+		break
+	}
+
+	if _, err := lb.Add("player6", 60, nil); err != nil {
+		t.Errorf("Failed to add after iteration: %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package rank
 
 import (
-	"errors"
 	"sync"
 	"time"
 )
@@ -16,6 +15,19 @@ type LeaderboardConfig struct {
 	ScoreOrder bool
 	// UpdatePolicy policy for handling score updates
 	UpdatePolicy UpdatePolicy
+	// TieBreak ordering of members with the same score
+	TieBreak TieBreak
+	// MaxOvertaken maximum number of overtaken members reported by each write, 0 disables the report
+	MaxOvertaken int
+	// RankingMode ranking method for members with the same score
+	RankingMode RankingMode
+	// MaxMembers maximum number of members, 0 for no limit. When the leaderboard is full, a new member evicts
+	// the last ranked member, or is rejected with ErrBelowCutoff if it would be ranked last itself
+	MaxMembers int
+	// MemberTTL time after which a member that has not been updated expires, 0 for no expiry
+	MemberTTL time.Duration
+	// Codec encoding of the additional data in snapshots, JSON if nil
+	Codec Codec
 }
 
 // UpdatePolicy score update policy
@@ -30,210 +42,608 @@ const (
 	UpdateAlways
 )
 
-// MemberData leaderboard member data
-type MemberData struct {
+// TieBreak tie-break strategy for members with the same score
+type TieBreak int
+
+const (
+	// TieBreakMemberAsc members with the same score are ranked by member ID in ascending lexicographic order
+	TieBreakMemberAsc TieBreak = iota
+	// TieBreakMemberDesc members with the same score are ranked by member ID in descending lexicographic order
+	TieBreakMemberDesc
+	// TieBreakEarliest the member who reached the score first is ranked higher
+	TieBreakEarliest
+	// TieBreakLatest the member who reached the score last is ranked higher
+	TieBreakLatest
+)
+
+// comparator returns the skip list comparator implementing the tie-break strategy
+func comparator[D any](tieBreak TieBreak) Comparator[string, int64, TypedMemberData[D]] {
+	switch tieBreak {
+	case TieBreakMemberDesc:
+		return MemberDesc[string, int64, TypedMemberData[D]]
+	case TieBreakEarliest:
+		return func(a, b *TypedElement[string, int64, TypedMemberData[D]]) int {
+			return a.Data.UpdatedAt.Compare(b.Data.UpdatedAt)
+		}
+	case TieBreakLatest:
+		return func(a, b *TypedElement[string, int64, TypedMemberData[D]]) int {
+			return b.Data.UpdatedAt.Compare(a.Data.UpdatedAt)
+		}
+	default:
+		return MemberAsc[string, int64, TypedMemberData[D]]
+	}
+}
+
+// MemberData member data of a leaderboard with untyped additional data
+type MemberData = TypedMemberData[interface{}]
+
+// TypedMemberData leaderboard member data
+type TypedMemberData[D any] struct {
 	// Member member identifier
 	Member string
 	// Score member's score
 	Score int64
 	// Data additional data
-	Data interface{}
+	Data D
 	// UpdatedAt last update time
 	UpdatedAt time.Time
+	// ExpiresAt time at which the member expires, zero if it never expires
+	ExpiresAt time.Time
 }
 
-// RankData ranking data
-type RankData struct {
+// RankData ranking data of a leaderboard with untyped additional data
+type RankData = TypedRankData[interface{}]
+
+// TypedRankData ranking data
+type TypedRankData[D any] struct {
 	// Rank position in the leaderboard
 	Rank int64
 	// Member member data
-	MemberData
+	TypedMemberData[D]
 }
 
-// Leaderboard implementation
-type Leaderboard struct {
+// AddStatus outcome of a single write
+type AddStatus int
+
+const (
+	// AddApplied the score was written
+	AddApplied AddStatus = iota
+	// AddRejected the score was rejected by the update policy
+	AddRejected
+	// AddFailed the score could not be written
+	AddFailed
+)
+
+// AddResult outcome of a single score update
+type AddResult[D any] struct {
+	// Status whether the score was applied, rejected or failed
+	Status AddStatus
+	// Err reason for the rejection or failure, nil when applied
+	Err error
+	// IsNew whether the member didn't exist before the write
+	IsNew bool
+	// PrevScore score before the write, 0 for new members
+	PrevScore int64
+	// PrevRank rank before the write, 0 for new members or when ranks are not requested
+	PrevRank int64
+	// Overtaken members that were ranked before the member and are now ranked after it, closest first,
+	// at most MaxOvertaken members are reported
+	Overtaken []string
+	// Evicted member removed to make room for a new member in a full leaderboard, nil if none
+	Evicted *TypedMemberData[D]
+	// RankData member's current standing, which is the previous standing when the score was not applied.
+	// Rank is 0 when ranks are not requested
+	TypedRankData[D]
+}
+
+// Applied reports whether the score was written
+func (r *AddResult[D]) Applied() bool {
+	return r.Status == AddApplied
+}
+
+// Leaderboard leaderboard with untyped additional data
+type Leaderboard = TypedLeaderboard[interface{}]
+
+// TypedLeaderboard implementation, D is the type of the additional member data
+type TypedLeaderboard[D any] struct {
 	// config configuration information
 	config LeaderboardConfig
 	// skipList underlying skip list storage
-	skipList *SkipList
+	skipList *TypedSkipList[string, int64, TypedMemberData[D]]
+	// scores distinct scores with their number of members, only kept for dense ranking
+	scores *TypedSkipList[int64, int64, int]
+	// expiry members with an expiry time
+	expiry *expiryQueue
+	// wal write-ahead log, nil if the leaderboard is not durable
+	wal *writeAheadLog
+	// frozen whether writes are refused, see Freeze
+	frozen bool
+	// view whether this is a point-in-time view sharing the skip lists and the mutex of a leaderboard, see Snapshot
+	view bool
 	// mutex mutex for thread safety
-	mutex sync.RWMutex
+	mutex *sync.RWMutex
+	// now returns the current time, used for UpdatedAt
+	now func() time.Time
 }
 
-// NewLeaderboard creates a new leaderboard
+// NewLeaderboard creates a new leaderboard with untyped additional data
 func NewLeaderboard(config LeaderboardConfig) *Leaderboard {
-	return &Leaderboard{
+	return NewTypedLeaderboard[interface{}](config)
+}
+
+// NewTypedLeaderboard creates a new leaderboard whose additional data is of type D
+func NewTypedLeaderboard[D any](config LeaderboardConfig) *TypedLeaderboard[D] {
+	return &TypedLeaderboard[D]{
 		config:   config,
-		skipList: NewSkipList(),
-		mutex:    sync.RWMutex{},
+		skipList: newSkipList[D](config),
+		scores:   newScoreCounter(config),
+		expiry:   newExpiryQueue(),
+		mutex:    &sync.RWMutex{},
+		now:      time.Now,
 	}
 }
 
-// Add adds or updates a member's score
-func (lb *Leaderboard) Add(member string, score int64, data interface{}) (*RankData, error) {
-	lb.mutex.Lock()
+// newSkipList creates the underlying skip list for a leaderboard configuration
+func newSkipList[D any](config LeaderboardConfig) *TypedSkipList[string, int64, TypedMemberData[D]] {
+	return NewSkipListWithConfig(skipListConfig[D](config))
+}
+
+// skipListConfig gets the configuration of the skip list of a leaderboard
+func skipListConfig[D any](config LeaderboardConfig) SkipListConfig[string, int64, TypedMemberData[D]] {
+	return SkipListConfig[string, int64, TypedMemberData[D]]{
+		Ascending:  !config.ScoreOrder,
+		Comparator: comparator[D](config.TieBreak),
+	}
+}
+
+// Add adds or updates a member's score. The result always holds the member's current standing,
+// even when the score is rejected by the update policy, in which case the error is also returned
+func (lb *TypedLeaderboard[D]) Add(member string, score int64, data D) (*AddResult[D], error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	result := lb.add(member, score, data, 0, true)
+	return &result, result.Err
+}
+
+// Incr atomically adds delta to a member's score, the member is created with a score of delta if it doesn't exist.
+// The new score is subject to the update policy, like Add
+func (lb *TypedLeaderboard[D]) Incr(member string, delta int64, data D) (*AddResult[D], error) {
+	lb.lockWrite()
 	defer lb.mutex.Unlock()
 
+	score := delta
+	if existing := lb.skipList.GetElementByMember(member); existing != nil {
+		score = existing.Score + delta
+		// Check for overflow
+		if (delta > 0 && score < existing.Score) || (delta < 0 && score > existing.Score) {
+			result := lb.unchanged(existing, AddFailed, ErrScoreOverflow, true)
+			return &result, result.Err
+		}
+	}
+
+	result := lb.add(member, score, data, 0, true)
+	return &result, result.Err
+}
+
+// add adds or updates a member's score, ranks are only computed when withRank is true.
+// A ttl of 0 or less uses the MemberTTL of the configuration. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) add(member string, score int64, data D, ttl time.Duration, withRank bool) AddResult[D] {
 	// Check if member already exists
 	existing := lb.skipList.GetElementByMember(member)
 
-	// Decide whether to update based on update policy
+	if lb.frozen {
+		return lb.failed(existing, member, ErrFrozen, withRank)
+	}
+
+	result := AddResult[D]{
+		Status: AddApplied,
+		IsNew:  existing == nil,
+	}
+
+	var prevPosition int64
 	if existing != nil {
-		var existingScore int64
-		if md, ok := existing.Data.(MemberData); ok {
-			existingScore = md.Score // This is the actual score, not the potentially inverted score stored in the skip list
-		} else {
-			existingScore = existing.Score
+		// Decide whether to update based on update policy
+		if err := lb.checkPolicy(existing.Score, score); err != nil {
+			return lb.unchanged(existing, AddRejected, err, withRank)
 		}
 
-		switch lb.config.UpdatePolicy {
-		case UpdateIfHigher:
-			// High score priority: new score must be higher
-			// Low score priority: new score must be lower (smaller scores are considered "higher")
-			if lb.config.ScoreOrder && score <= existingScore {
-				return nil, errors.New("new score is not higher than existing score")
-			}
-			if !lb.config.ScoreOrder && score >= existingScore {
-				return nil, errors.New("new score is not lower than existing score")
-			}
-		case UpdateIfLower:
-			// High score priority: new score must be lower (smaller)
-			// Low score priority: new score must be higher (higher times are worse)
-			if lb.config.ScoreOrder && score >= existingScore {
-				return nil, errors.New("new score is not lower than existing score")
-			}
-			if !lb.config.ScoreOrder && score <= existingScore {
-				return nil, errors.New("new score is not higher than existing score")
-			}
+		result.PrevScore = existing.Score
+		if withRank {
+			prevPosition = lb.skipList.GetRank(member, existing.Score)
+			result.PrevRank = lb.rankAt(existing, prevPosition)
 		}
 	}
 
-	// Adapt score ordering: skip list always keeps high scores at the front,
-	// so for low-score-first leaderboards, we need to invert the score
-	skipListScore := score
-	if !lb.config.ScoreOrder {
-		skipListScore = -score
-	}
-
-	// Update element
-	memberData := MemberData{
+	// Update element. The monotonic clock reading is stripped, so that update times are ordered by wall time in
+	// memory as they are in snapshots, even if the wall clock was set back
+	now := lb.now().Round(0)
+	memberData := TypedMemberData[D]{
 		Member:    member,
-		Score:     score, // Store original score
+		Score:     score,
 		Data:      data,
-		UpdatedAt: time.Now(),
+		UpdatedAt: now,
+		ExpiresAt: lb.expiresAt(now, ttl),
+	}
+
+	// A failed write-ahead log stops all writes until it is reopened. The record is encoded before a member
+	// is evicted, so that an eviction is only made for a write that can be logged
+	if err := lb.walError(); err != nil {
+		return lb.failed(existing, member, err, withRank)
+	}
+	record, err := lb.setRecord(memberData)
+	if err != nil {
+		return lb.failed(existing, member, err, withRank)
+	}
+
+	// Make room for a new member in a full leaderboard
+	if existing == nil && lb.config.MaxMembers > 0 && lb.skipList.Len() >= uint64(lb.config.MaxMembers) {
+		last := &lb.skipList.tail.element
+		probe := &TypedElement[string, int64, TypedMemberData[D]]{Member: member, Score: score, Data: memberData}
+		if lb.skipList.compare(probe, last) > 0 {
+			result.Status = AddRejected
+			result.Err = &BelowCutoffError{Score: score, Cutoff: last.Score, MaxMembers: lb.config.MaxMembers}
+			result.Member = member
+			return result
+		}
+
+		evicted := last.Data
+		if err := lb.delete(last); err != nil {
+			return lb.failed(existing, member, err, withRank)
+		}
+		result.Evicted = &evicted
 	}
 
-	lb.skipList.Insert(member, skipListScore, memberData)
+	element, err := lb.insertRecord(memberData, record)
+	if err != nil {
+		failed := lb.failed(existing, member, err, withRank)
+		failed.Evicted = result.Evicted
+		return failed
+	}
+	result.TypedMemberData = memberData
 
 	// Get rank
-	rank := lb.skipList.GetRank(member, skipListScore)
+	if withRank {
+		position := lb.skipList.GetRank(member, score)
+		result.Rank = lb.rankAt(element, position)
+		if !result.IsNew && position < prevPosition {
+			result.Overtaken = lb.overtaken(position, prevPosition)
+		}
+	}
 
-	return &RankData{
-		Rank:       rank,
-		MemberData: memberData,
-	}, nil
+	return result
 }
 
-// Remove removes a member
-func (lb *Leaderboard) Remove(member string) bool {
-	lb.mutex.Lock()
+// insert writes a member to the skip list, replacing the existing one, after logging it to the write-ahead log
+// if any. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) insert(memberData TypedMemberData[D]) (*TypedElement[string, int64, TypedMemberData[D]], error) {
+	record, err := lb.setRecord(memberData)
+	if err != nil {
+		return nil, err
+	}
+	return lb.insertRecord(memberData, record)
+}
+
+// insertRecord writes a member to the skip list like insert, with its write-ahead log record already encoded.
+// The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) insertRecord(memberData TypedMemberData[D], record []byte) (*TypedElement[string, int64, TypedMemberData[D]], error) {
+	if err := lb.appendRecord(record); err != nil {
+		return nil, err
+	}
+
+	if existing := lb.skipList.GetElementByMember(memberData.Member); existing != nil {
+		lb.untrackScore(existing.Score)
+	}
+	lb.trackScore(memberData.Score)
+	lb.expiry.set(memberData.Member, memberData.ExpiresAt)
+
+	element := lb.skipList.Insert(memberData.Member, memberData.Score, memberData)
+	lb.maybeCompact()
+
+	return element, nil
+}
+
+// delete removes a member from the skip list, after logging its removal to the write-ahead log if any.
+// The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) delete(element *TypedElement[string, int64, TypedMemberData[D]]) error {
+	return lb.remove([]*TypedElement[string, int64, TypedMemberData[D]]{element})
+}
+
+// remove removes distinct members from the skip list, after logging their removal to the write-ahead log
+// if any. Nothing is removed if the removal can't be logged. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) remove(elements []*TypedElement[string, int64, TypedMemberData[D]]) error {
+	if lb.frozen {
+		return ErrFrozen
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+
+	if err := lb.logDelete(elements); err != nil {
+		return err
+	}
+
+	for _, element := range elements {
+		lb.skipList.Delete(element.Member, element.Score)
+		lb.deleted(element)
+	}
+	lb.maybeCompact()
+
+	return nil
+}
+
+// removeRange removes the members within a range of ordinal positions in a single walk, after logging their
+// removal like remove, and returns them in rank order. The ranks they had are only set when ranked is true.
+// The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) removeRange(start, end int64, ranked bool) ([]*TypedRankData[D], error) {
+	if lb.frozen {
+		return nil, ErrFrozen
+	}
+
+	run := lb.skipList.seekRankRange(start, end)
+	if len(run.elements) == 0 {
+		return []*TypedRankData[D]{}, nil
+	}
+
+	if err := lb.logDelete(run.elements); err != nil {
+		return nil, err
+	}
+
+	// Ranks are taken before the members are removed
+	var result []*TypedRankData[D]
+	if ranked {
+		result = lb.rankList(run.start, run.elements)
+	} else {
+		result = make([]*TypedRankData[D], 0, len(run.elements))
+		for _, element := range run.elements {
+			result = append(result, &TypedRankData[D]{TypedMemberData: element.Data})
+		}
+	}
+
+	lb.skipList.unlinkRun(run)
+	for _, element := range run.elements {
+		lb.deleted(element)
+	}
+	lb.maybeCompact()
+
+	return result, nil
+}
+
+// deleted updates the bookkeeping for an element already unlinked from the skip list. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) deleted(element *TypedElement[string, int64, TypedMemberData[D]]) {
+	lb.untrackScore(element.Score)
+	lb.expiry.remove(element.Member)
+}
+
+// overtaken gets the members overtaken by a member that climbed from the ordinal position prevRank to rank,
+// bounded by MaxOvertaken
+func (lb *TypedLeaderboard[D]) overtaken(rank, prevRank int64) []string {
+	if lb.config.MaxOvertaken <= 0 {
+		return nil
+	}
+
+	// The overtaken members are now right after the member, up to its previous rank
+	end := min(prevRank, rank+int64(lb.config.MaxOvertaken))
+	members := make([]string, 0, end-rank)
+	for r, element := range lb.skipList.FromRank(rank + 1) {
+		if r > end {
+			break
+		}
+		members = append(members, element.Member)
+	}
+
+	return members
+}
+
+// unchanged creates the result of a write that was not applied to an existing member
+func (lb *TypedLeaderboard[D]) unchanged(existing *TypedElement[string, int64, TypedMemberData[D]], status AddStatus, err error, withRank bool) AddResult[D] {
+	result := AddResult[D]{
+		Status:        status,
+		Err:           err,
+		PrevScore:     existing.Score,
+		TypedRankData: TypedRankData[D]{TypedMemberData: existing.Data},
+	}
+
+	if withRank {
+		result.Rank = lb.rank(existing)
+		result.PrevRank = result.Rank
+	}
+
+	return result
+}
+
+// failed gets the result of a write that could not be applied, for an existing or a new member
+func (lb *TypedLeaderboard[D]) failed(existing *TypedElement[string, int64, TypedMemberData[D]], member string, err error, withRank bool) AddResult[D] {
+	if existing != nil {
+		return lb.unchanged(existing, AddFailed, err, withRank)
+	}
+
+	return AddResult[D]{
+		Status:        AddFailed,
+		Err:           err,
+		IsNew:         true,
+		TypedRankData: TypedRankData[D]{TypedMemberData: TypedMemberData[D]{Member: member}},
+	}
+}
+
+// checkPolicy checks whether the update policy accepts replacing the existing score with the new score
+func (lb *TypedLeaderboard[D]) checkPolicy(existingScore, score int64) error {
+	var higher bool
+	switch lb.config.UpdatePolicy {
+	case UpdateIfHigher:
+		// High score priority: new score must be higher
+		// Low score priority: new score must be lower (smaller scores are considered "higher")
+		higher = lb.config.ScoreOrder
+	case UpdateIfLower:
+		// High score priority: new score must be lower (smaller)
+		// Low score priority: new score must be higher (higher times are worse)
+		higher = !lb.config.ScoreOrder
+	default:
+		return nil
+	}
+
+	if (higher && score <= existingScore) || (!higher && score >= existingScore) {
+		return &ScoreRejectedError{
+			OldScore: existingScore,
+			NewScore: score,
+			Policy:   lb.config.UpdatePolicy,
+			higher:   higher,
+		}
+	}
+	return nil
+}
+
+// Remove removes a member, and reports whether it existed. ErrFrozen is returned if the leaderboard is frozen,
+// or the error of the write-ahead log, in which case the member is kept
+func (lb *TypedLeaderboard[D]) Remove(member string) (bool, error) {
+	lb.lockWrite()
 	defer lb.mutex.Unlock()
 
+	if lb.frozen {
+		return false, ErrFrozen
+	}
+
 	element := lb.skipList.GetElementByMember(member)
 	if element == nil {
-		return false
+		return false, nil
 	}
 
-	return lb.skipList.Delete(member, element.Score)
+	if err := lb.delete(element); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PopTop removes the n top ranked members, and returns them in rank order with the ranks they had.
+// Errors are reported like Remove
+func (lb *TypedLeaderboard[D]) PopTop(n int64) ([]*TypedRankData[D], error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	return lb.removeRange(1, n, true)
+}
+
+// PopBottom removes the n bottom ranked members, and returns them in rank order with the ranks they had.
+// Errors are reported like Remove
+func (lb *TypedLeaderboard[D]) PopBottom(n int64) ([]*TypedRankData[D], error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	// A count of 0 or less starts past the last member, so that nobody is removed
+	total := int64(lb.skipList.Len())
+	return lb.removeRange(total-max(n, 0)+1, total, true)
+}
+
+// RemoveRankRange removes the members within a rank range (by ordinal position), and returns the number of members removed.
+// Errors are reported like Remove
+func (lb *TypedLeaderboard[D]) RemoveRankRange(start, end int64) (int, error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	removed, err := lb.removeRange(start, end, false)
+	return len(removed), err
+}
+
+// RemoveScoreRange removes the members within a score range (inclusive), and returns the number of members removed.
+// Errors are reported like Remove
+func (lb *TypedLeaderboard[D]) RemoveScoreRange(min, max int64) (int, error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	start, end := lb.skipList.scoreRangePositions(min, max, false, false)
+	removed, err := lb.removeRange(start, end, false)
+	return len(removed), err
 }
 
 // GetRank gets a member's rank
-func (lb *Leaderboard) GetRank(member string) (int64, error) {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetRank(member string) (int64, error) {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
 	element := lb.skipList.GetElementByMember(member)
 	if element == nil {
-		return 0, errors.New("member does not exist")
+		return 0, ErrMemberNotFound
 	}
 
-	rank := lb.skipList.GetRank(member, element.Score)
+	rank := lb.rank(element)
 	return rank, nil
 }
 
 // GetMember gets a member's data
-func (lb *Leaderboard) GetMember(member string) (*MemberData, error) {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetMember(member string) (*TypedMemberData[D], error) {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
 	element := lb.skipList.GetElementByMember(member)
 	if element == nil {
-		return nil, errors.New("member does not exist")
-	}
-
-	if data, ok := element.Data.(MemberData); ok {
-		return &data, nil
+		return nil, ErrMemberNotFound
 	}
 
-	return nil, errors.New("data type error")
+	data := element.Data
+	return &data, nil
 }
 
 // GetMemberAndRank gets a member's data and rank
-func (lb *Leaderboard) GetMemberAndRank(member string) (*RankData, error) {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetMemberAndRank(member string) (*TypedRankData[D], error) {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
 	element := lb.skipList.GetElementByMember(member)
 	if element == nil {
-		return nil, errors.New("member does not exist")
+		return nil, ErrMemberNotFound
 	}
 
-	rank := lb.skipList.GetRank(member, element.Score)
-
-	if data, ok := element.Data.(MemberData); ok {
-		return &RankData{
-			Rank:       rank,
-			MemberData: data,
-		}, nil
-	}
+	rank := lb.rank(element)
 
-	return nil, errors.New("data type error")
+	return &TypedRankData[D]{
+		Rank:            rank,
+		TypedMemberData: element.Data,
+	}, nil
 }
 
 // GetRankList gets a list of rankings
-func (lb *Leaderboard) GetRankList(start, end int64) ([]*RankData, error) {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetRankList(start, end int64) ([]*TypedRankData[D], error) {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
-	elements := lb.skipList.GetRankRange(start, end)
-	result := make([]*RankData, 0, len(elements))
+	return lb.getRankList(start, end), nil
+}
 
-	for _, element := range elements {
-		// Calculate rank correctly
-		member := element.Member
-		score := element.Score
-		rank := lb.skipList.GetRank(member, score)
-
-		if data, ok := element.Data.(MemberData); ok {
-			result = append(result, &RankData{
-				Rank:       rank,
-				MemberData: data,
-			})
-		}
+// getRankList gets a list of rankings between two ordinal positions, the caller must hold the lock
+func (lb *TypedLeaderboard[D]) getRankList(start, end int64) []*TypedRankData[D] {
+	if start < 1 {
+		start = 1
 	}
 
-	return result, nil
+	return lb.rankList(start, lb.skipList.GetRankRange(start, end))
+}
+
+// rankList gets the rankings of consecutive elements starting at an ordinal position, the caller must hold the lock
+func (lb *TypedLeaderboard[D]) rankList(start int64, elements []*TypedElement[string, int64, TypedMemberData[D]]) []*TypedRankData[D] {
+	result := make([]*TypedRankData[D], 0, len(elements))
+
+	// Elements are consecutive, so ranks are derived by counting from the start rank
+	r := ranker[D]{lb: lb}
+	for i, element := range elements {
+		result = append(result, &TypedRankData[D]{
+			Rank:            r.next(start+int64(i), element),
+			TypedMemberData: element.Data,
+		})
+	}
+
+	return result
 }
 
 // GetAroundMember gets a list of rankings around a specified member
-func (lb *Leaderboard) GetAroundMember(member string, count int64) ([]*RankData, error) {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetAroundMember(member string, count int64) ([]*TypedRankData[D], error) {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
-	// Get member's rank
+	// Get member's ordinal position
 	element := lb.skipList.GetElementByMember(member)
 	if element == nil {
-		return nil, errors.New("member does not exist")
+		return nil, ErrMemberNotFound
 	}
 
 	rank := lb.skipList.GetRank(member, element.Score)
@@ -250,21 +660,38 @@ func (lb *Leaderboard) GetAroundMember(member string, count int64) ([]*RankData,
 	}
 
 	// Get rank list
-	return lb.GetRankList(start, end)
+	return lb.getRankList(start, end), nil
 }
 
 // GetTotal gets the total number of members in the leaderboard
-func (lb *Leaderboard) GetTotal() uint64 {
-	lb.mutex.RLock()
+func (lb *TypedLeaderboard[D]) GetTotal() uint64 {
+	lb.lockRead()
 	defer lb.mutex.RUnlock()
 
 	return lb.skipList.Len()
 }
 
-// Reset resets the leaderboard
-func (lb *Leaderboard) Reset() {
+// Reset removes all the members. Errors are reported like Remove, in which case no member is removed
+func (lb *TypedLeaderboard[D]) Reset() error {
 	lb.mutex.Lock()
 	defer lb.mutex.Unlock()
 
-	lb.skipList = NewSkipList()
+	if lb.frozen {
+		return ErrFrozen
+	}
+
+	if err := lb.logReset(); err != nil {
+		return err
+	}
+	lb.reset()
+	lb.maybeCompact()
+
+	return nil
+}
+
+// reset removes all members. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) reset() {
+	lb.skipList = newSkipList[D](lb.config)
+	lb.scores = newScoreCounter(lb.config)
+	lb.expiry = newExpiryQueue()
 }
@@ -1,7 +1,11 @@
 package rank
 
 import (
+	"errors"
+	"math"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestLeaderboardBasic(t *testing.T) {
@@ -93,9 +97,12 @@ func TestLeaderboardBasic(t *testing.T) {
 	}
 
 	// Test removing member
-	removed := lb.Remove("member3")
-	if !removed {
-		t.Error("Failed to remove member3")
+	removed, err := lb.Remove("member3")
+	if err != nil || !removed {
+		t.Errorf("Failed to remove member3: %v", err)
+	}
+	if removed, err := lb.Remove("member3"); err != nil || removed {
+		t.Errorf("Expected member3 to be gone, got %v %v", removed, err)
 	}
 
 	if lb.GetTotal() != 2 {
@@ -103,7 +110,9 @@ func TestLeaderboardBasic(t *testing.T) {
 	}
 
 	// Test reset
-	lb.Reset()
+	if err := lb.Reset(); err != nil {
+		t.Errorf("Failed to reset: %v", err)
+	}
 	if lb.GetTotal() != 0 {
 		t.Errorf("Expected total 0 after reset, got %d", lb.GetTotal())
 	}
@@ -259,3 +268,530 @@ func TestLeaderboardScoreOrder(t *testing.T) {
 		t.Errorf("Expected player2 to be rank 3, got %d", rank)
 	}
 }
+
+func TestLeaderboardTyped(t *testing.T) {
+	type playerInfo struct {
+		Nickname string
+		Level    int
+	}
+
+	lb := NewTypedLeaderboard[playerInfo](LeaderboardConfig{
+		ID:           "typed",
+		Name:         "Typed Leaderboard",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	lb.Add("player1", 100, playerInfo{Nickname: "One", Level: 1})
+	lb.Add("player2", 200, playerInfo{Nickname: "Two", Level: 2})
+
+	// Test getting typed member data
+	memberData, err := lb.GetMember("player1")
+	if err != nil {
+		t.Fatalf("Failed to get member: %v", err)
+	}
+
+	if memberData.Data.Nickname != "One" {
+		t.Errorf("Expected nickname 'One', got %s", memberData.Data.Nickname)
+	}
+
+	// Test getting typed rank list
+	rankList, err := lb.GetRankList(1, 2)
+	if err != nil {
+		t.Fatalf("Failed to get rank list: %v", err)
+	}
+
+	if len(rankList) != 2 {
+		t.Fatalf("Expected 2 items in rank list, got %d", len(rankList))
+	}
+
+	if rankList[0].Data.Level != 2 {
+		t.Errorf("Expected level 2 at rank 1, got %d", rankList[0].Data.Level)
+	}
+}
+
+func TestLeaderboardTieBreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		tieBreak TieBreak
+		expected []string
+	}{
+		{"MemberAsc", TieBreakMemberAsc, []string{"top", "a", "b", "c"}},
+		{"MemberDesc", TieBreakMemberDesc, []string{"top", "c", "b", "a"}},
+		{"Earliest", TieBreakEarliest, []string{"top", "b", "c", "a"}},
+		{"Latest", TieBreakLatest, []string{"top", "a", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := NewLeaderboard(LeaderboardConfig{
+				ID:           "tie_break",
+				Name:         "Tie Break Test",
+				ScoreOrder:   true,
+				UpdatePolicy: UpdateAlways,
+				TieBreak:     tt.tieBreak,
+			})
+
+			// Use a fake clock so that every add happens at a distinct time
+			now := time.Unix(1700000000, 0)
+			lb.now = func() time.Time {
+				now = now.Add(time.Second)
+				return now
+			}
+
+			lb.Add("b", 100, nil)
+			lb.Add("c", 100, nil)
+			lb.Add("a", 100, nil)
+			lb.Add("top", 200, nil)
+
+			rankList, _ := lb.GetRankList(1, 4)
+			if len(rankList) != len(tt.expected) {
+				t.Fatalf("Expected %d items in rank list, got %d", len(tt.expected), len(rankList))
+			}
+
+			for i, member := range tt.expected {
+				if rankList[i].Member != member {
+					t.Errorf("Expected %s at rank %d, got %s", member, i+1, rankList[i].Member)
+				}
+
+				rank, _ := lb.GetRank(member)
+				if rank != int64(i+1) {
+					t.Errorf("Expected rank %d for %s, got %d", i+1, member, rank)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderboardScoreOrderExtremes(t *testing.T) {
+	lowLB := NewLeaderboard(LeaderboardConfig{
+		ID:           "low_extremes",
+		Name:         "Low Score First Extremes",
+		ScoreOrder:   false,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	lowLB.Add("min", math.MinInt64, nil)
+	lowLB.Add("zero", 0, nil)
+	lowLB.Add("max", math.MaxInt64, nil)
+
+	// The lowest possible score must be ranked first
+	rank, _ := lowLB.GetRank("min")
+	if rank != 1 {
+		t.Errorf("Expected min to be rank 1, got %d", rank)
+	}
+
+	rank, _ = lowLB.GetRank("max")
+	if rank != 3 {
+		t.Errorf("Expected max to be rank 3, got %d", rank)
+	}
+
+	// The stored score is the real score
+	memberData, _ := lowLB.GetMember("min")
+	if memberData.Score != math.MinInt64 {
+		t.Errorf("Expected score %d, got %d", int64(math.MinInt64), memberData.Score)
+	}
+
+	element := lowLB.skipList.GetElementByMember("max")
+	if element.Score != math.MaxInt64 || element.Score != element.Data.Score {
+		t.Errorf("Expected skip list score %d to match member score %d", element.Score, element.Data.Score)
+	}
+}
+
+func TestLeaderboardGetRankListRanks(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "rank_list",
+		Name:         "Rank List Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	for i := 1; i <= 10; i++ {
+		lb.Add("player"+string(rune('a'+i-1)), int64(i*100), nil)
+	}
+
+	// Test a page in the middle of the leaderboard
+	rankList, _ := lb.GetRankList(3, 6)
+	if len(rankList) != 4 {
+		t.Fatalf("Expected 4 items in rank list, got %d", len(rankList))
+	}
+
+	for i, item := range rankList {
+		expectedRank := int64(3 + i)
+		if item.Rank != expectedRank {
+			t.Errorf("Expected rank %d, got %d", expectedRank, item.Rank)
+		}
+
+		rank, _ := lb.GetRank(item.Member)
+		if rank != item.Rank {
+			t.Errorf("Expected rank list rank %d to match GetRank %d for %s", item.Rank, rank, item.Member)
+		}
+	}
+
+	// Test boundary conditions
+	rankList, _ = lb.GetRankList(0, 2)
+	if len(rankList) != 2 || rankList[0].Rank != 1 {
+		t.Errorf("Expected 2 items starting at rank 1, got %v", rankList)
+	}
+
+	rankList, _ = lb.GetRankList(9, 20)
+	if len(rankList) != 2 || rankList[1].Rank != 10 {
+		t.Errorf("Expected 2 items ending at rank 10, got %v", rankList)
+	}
+}
+
+func TestLeaderboardIncr(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "incr",
+		Name:         "Incr Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	// Test creating a member
+	rankData, err := lb.Incr("player1", 100, "data1")
+	if err != nil {
+		t.Fatalf("Failed to incr member: %v", err)
+	}
+
+	if rankData.Score != 100 || rankData.Rank != 1 {
+		t.Errorf("Expected score 100 and rank 1, got %d and %d", rankData.Score, rankData.Rank)
+	}
+
+	lb.Add("player2", 150, "data2")
+
+	// Test adding to an existing score
+	rankData, err = lb.Incr("player1", 80, "data1")
+	if err != nil {
+		t.Fatalf("Failed to incr member: %v", err)
+	}
+
+	if rankData.Score != 180 || rankData.Rank != 1 {
+		t.Errorf("Expected score 180 and rank 1, got %d and %d", rankData.Score, rankData.Rank)
+	}
+
+	// Test negative delta
+	rankData, _ = lb.Incr("player1", -100, "data1")
+	if rankData.Score != 80 || rankData.Rank != 2 {
+		t.Errorf("Expected score 80 and rank 2, got %d and %d", rankData.Score, rankData.Rank)
+	}
+
+	// Test overflow
+	lb.Add("player3", math.MaxInt64-1, nil)
+	if _, err := lb.Incr("player3", 2, nil); err == nil {
+		t.Error("Expected error on score overflow")
+	}
+
+	// Test update policy
+	higherLB := NewLeaderboard(LeaderboardConfig{
+		ID:           "incr_higher",
+		Name:         "Incr Higher Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateIfHigher,
+	})
+
+	higherLB.Incr("player1", 100, nil)
+	if _, err := higherLB.Incr("player1", -10, nil); err == nil {
+		t.Error("Expected error when decreasing score with UpdateIfHigher policy")
+	}
+
+	memberData, _ := higherLB.GetMember("player1")
+	if memberData.Score != 100 {
+		t.Errorf("Expected score 100 after rejected incr, got %d", memberData.Score)
+	}
+}
+
+func TestLeaderboardIncrConcurrent(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "incr_concurrent",
+		Name:         "Concurrent Incr Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				lb.Incr("player1", 1, nil)
+			}
+		}()
+	}
+	wg.Wait()
+
+	memberData, _ := lb.GetMember("player1")
+	if memberData.Score != 5000 {
+		t.Errorf("Expected score 5000, got %d", memberData.Score)
+	}
+}
+
+func TestLeaderboardAddResult(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "add_result",
+		Name:         "Add Result Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateIfHigher,
+	})
+
+	// Test adding a new member
+	result, err := lb.Add("player1", 100, "data1")
+	if err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+
+	if !result.Applied() || !result.IsNew || result.PrevRank != 0 || result.PrevScore != 0 {
+		t.Errorf("Expected a new applied member without previous standing, got %+v", result)
+	}
+
+	lb.Add("player2", 200, "data2")
+
+	// Test a rejected score, the current standing is still returned
+	result, err = lb.Add("player1", 50, "ignored")
+	if err == nil {
+		t.Fatal("Expected error when adding lower score with UpdateIfHigher policy")
+	}
+
+	if result == nil {
+		t.Fatal("Expected non-nil result for a rejected score")
+	}
+
+	if result.Applied() || result.Status != AddRejected || result.Err != err {
+		t.Errorf("Expected rejected status with the returned error, got %d: %v", result.Status, result.Err)
+	}
+
+	if result.Score != 100 || result.Rank != 2 || result.Data != "data1" {
+		t.Errorf("Expected current score 100 at rank 2 with data1, got %d at rank %d with %v", result.Score, result.Rank, result.Data)
+	}
+
+	if result.PrevScore != 100 || result.PrevRank != 2 || result.IsNew {
+		t.Errorf("Expected previous score 100 at rank 2, got %d at rank %d", result.PrevScore, result.PrevRank)
+	}
+
+	// Test an applied update reports the previous standing
+	result, err = lb.Add("player1", 300, "data1")
+	if err != nil {
+		t.Fatalf("Failed to update member: %v", err)
+	}
+
+	if result.PrevScore != 100 || result.PrevRank != 2 || result.Score != 300 || result.Rank != 1 {
+		t.Errorf("Expected 100 at rank 2 to 300 at rank 1, got %d at rank %d to %d at rank %d",
+			result.PrevScore, result.PrevRank, result.Score, result.Rank)
+	}
+
+	// Test a failed increment also returns the current standing
+	lb.Add("player3", math.MaxInt64, nil)
+	result, err = lb.Incr("player3", 1, nil)
+	if err == nil || result == nil || result.Status != AddFailed || result.Rank != 1 {
+		t.Errorf("Expected failed increment with the current standing at rank 1, got %+v: %v", result, err)
+	}
+}
+
+func TestLeaderboardOvertaken(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "overtaken",
+		Name:         "Overtaken Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		MaxOvertaken: 2,
+	})
+
+	for i := 1; i <= 6; i++ {
+		lb.Add("player"+string(rune('0'+i)), int64(i*100), nil)
+	}
+
+	// player1 is last, climbing to rank 2 overtakes player5, player4, player3 and player2
+	result, err := lb.Add("player1", 550, nil)
+	if err != nil {
+		t.Fatalf("Failed to update member: %v", err)
+	}
+
+	if result.PrevRank != 6 || result.Rank != 2 || result.PrevScore != 100 {
+		t.Errorf("Expected rank 6 to 2 with previous score 100, got %d to %d with %d", result.PrevRank, result.Rank, result.PrevScore)
+	}
+
+	// Only the closest MaxOvertaken members are reported
+	if len(result.Overtaken) != 2 || result.Overtaken[0] != "player5" || result.Overtaken[1] != "player4" {
+		t.Errorf("Expected player5 and player4 to be overtaken, got %v", result.Overtaken)
+	}
+
+	// Climbing a single rank
+	result, _ = lb.Add("player5", 560, nil)
+	if len(result.Overtaken) != 1 || result.Overtaken[0] != "player1" {
+		t.Errorf("Expected player1 to be overtaken, got %v", result.Overtaken)
+	}
+
+	// Dropping in rank or joining the leaderboard overtakes nobody
+	result, _ = lb.Add("player6", 10, nil)
+	if len(result.Overtaken) != 0 || result.PrevRank != 1 || result.Rank != 6 {
+		t.Errorf("Expected nobody overtaken from rank 1 to 6, got %v from %d to %d", result.Overtaken, result.PrevRank, result.Rank)
+	}
+
+	result, _ = lb.Add("player7", 1000, nil)
+	if len(result.Overtaken) != 0 || !result.IsNew {
+		t.Errorf("Expected nobody overtaken by a new member, got %v", result.Overtaken)
+	}
+
+	// Test the report is disabled by default
+	disabled := NewLeaderboard(LeaderboardConfig{
+		ID:           "overtaken_disabled",
+		Name:         "Overtaken Disabled Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	disabled.Add("player1", 100, nil)
+	disabled.Add("player2", 200, nil)
+	result, _ = disabled.Add("player1", 300, nil)
+	if result.Overtaken != nil || result.PrevRank != 2 || result.Rank != 1 {
+		t.Errorf("Expected no overtaken report from rank 2 to 1, got %v from %d to %d", result.Overtaken, result.PrevRank, result.Rank)
+	}
+}
+
+func TestLeaderboardPopAndTrim(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "pop_trim",
+		Name:         "Pop And Trim Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingDense,
+	})
+
+	// Scores 100, 90, 90, 80, ..., 10
+	lb.Add("a", 100, nil)
+	lb.Add("b", 90, nil)
+	lb.Add("c", 90, nil)
+	for i := 8; i >= 1; i-- {
+		lb.Add(string(rune('0'+i)), int64(i*10), nil)
+	}
+
+	// Test popping the top, ranks are the ones the members had
+	popped, err := lb.PopTop(3)
+	if err != nil || len(popped) != 3 || popped[0].Member != "a" || popped[2].Member != "c" {
+		t.Fatalf("Expected a, b and c to be popped, got %v", popped)
+	}
+	if popped[0].Rank != 1 || popped[1].Rank != 2 || popped[2].Rank != 2 {
+		t.Errorf("Expected ranks 1, 2, 2, got %d, %d, %d", popped[0].Rank, popped[1].Rank, popped[2].Rank)
+	}
+
+	// The dense ranks of the remaining members start over
+	if rank, _ := lb.GetRank("8"); rank != 1 {
+		t.Errorf("Expected rank 1 after popping, got %d", rank)
+	}
+
+	// Test popping the bottom
+	popped, err = lb.PopBottom(2)
+	if err != nil || len(popped) != 2 || popped[0].Member != "2" || popped[1].Member != "1" {
+		t.Errorf("Expected 2 and 1 to be popped, got %v", popped)
+	}
+	if popped[0].Rank != 7 || popped[1].Rank != 8 {
+		t.Errorf("Expected ranks 7 and 8, got %d and %d", popped[0].Rank, popped[1].Rank)
+	}
+
+	// Test removing ranges
+	if removed, err := lb.RemoveScoreRange(50, 70); err != nil || removed != 3 {
+		t.Errorf("Expected 3 members to be removed, got %d %v", removed, err)
+	}
+	if removed, err := lb.RemoveRankRange(2, 10); err != nil || removed != 2 {
+		t.Errorf("Expected 2 members to be removed, got %d %v", removed, err)
+	}
+
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member left, got %d", total)
+	}
+
+	lb.Add("new", 60, nil)
+	if rank, _ := lb.GetRank("8"); rank != 1 {
+		t.Errorf("Expected rank 1, got %d", rank)
+	}
+	if rank, _ := lb.GetRank("new"); rank != 2 {
+		t.Errorf("Expected rank 2, got %d", rank)
+	}
+}
+
+func TestLeaderboardMaxMembers(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "max_members",
+		Name:         "Max Members Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		MaxMembers:   3,
+	})
+
+	lb.Add("a", 100, nil)
+	lb.Add("b", 90, nil)
+	lb.Add("c", 80, nil)
+
+	// Test rejecting a new member below the cutoff, ties are broken by member ID so "d" ranks after "c"
+	result, err := lb.Add("d", 80, nil)
+	if !errors.Is(err, ErrBelowCutoff) {
+		t.Errorf("Expected ErrBelowCutoff, got %v", err)
+	}
+
+	var cutoffErr *BelowCutoffError
+	if !errors.As(err, &cutoffErr) || cutoffErr.Cutoff != 80 || cutoffErr.MaxMembers != 3 {
+		t.Errorf("Expected cutoff 80 of 3 members, got %+v", cutoffErr)
+	}
+
+	if result.Status != AddRejected || result.Rank != 0 || result.Evicted != nil {
+		t.Errorf("Expected a rejected result without rank, got %+v", result)
+	}
+
+	// Test evicting the last member
+	result, err = lb.Add("e", 85, nil)
+	if err != nil {
+		t.Fatalf("Failed to add member: %v", err)
+	}
+
+	if result.Rank != 3 || result.Evicted == nil || result.Evicted.Member != "c" {
+		t.Errorf("Expected rank 3 with c evicted, got rank %d and %+v", result.Rank, result.Evicted)
+	}
+
+	if lb.GetTotal() != 3 {
+		t.Errorf("Expected 3 members, got %d", lb.GetTotal())
+	}
+
+	if _, err := lb.GetMember("c"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected c to be evicted, got %v", err)
+	}
+
+	// Test that existing members can still be updated, even to the last rank
+	result, err = lb.Add("a", 10, nil)
+	if err != nil || result.Rank != 3 || result.Evicted != nil {
+		t.Errorf("Expected a to be updated to rank 3 without eviction, got %+v, %v", result, err)
+	}
+
+	// Test that increments of new members are bounded too
+	if _, err := lb.Incr("f", 5, nil); !errors.Is(err, ErrBelowCutoff) {
+		t.Errorf("Expected ErrBelowCutoff, got %v", err)
+	}
+
+	if lb.GetTotal() != 3 {
+		t.Errorf("Expected 3 members, got %d", lb.GetTotal())
+	}
+}
+
+func TestUntypedAliases(t *testing.T) {
+	// Code written against the untyped types keeps building
+	var lb *Leaderboard = NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	lb.Add("a", 1, "data")
+
+	var memberData *MemberData
+	memberData, err := lb.GetMember("a")
+	if err != nil || memberData.Data != "data" {
+		t.Errorf("Expected a with its data, got %v %v", memberData, err)
+	}
+
+	var rankData *RankData
+	rankData, err = lb.GetMemberAndRank("a")
+	if err != nil || rankData.Rank != 1 || rankData.Member != "a" {
+		t.Errorf("Expected a at rank 1, got %v %v", rankData, err)
+	}
+
+	var sl *SkipList = NewSkipList()
+	var element *Element = sl.Insert("a", 1, "data")
+	if element.Member != "a" || sl.GetRank("a", 1) != 1 {
+		t.Errorf("Expected a at rank 1, got %v", element)
+	}
+}
@@ -0,0 +1,64 @@
+package rank
+
+import (
+	"math"
+)
+
+// percentilePosition converts a top percentile in (0, 100] into the ordinal position of the last element
+// within it, 0 if the percentile is out of range or there are no elements
+func percentilePosition(p float64, length uint64) int64 {
+	if length == 0 || math.IsNaN(p) || p <= 0 || p > 100 {
+		return 0
+	}
+
+	position := int64(math.Ceil(p / 100 * float64(length)))
+	return max(1, min(position, int64(length)))
+}
+
+// GetScoreAtPercentile gets the score of the last element within the top p percent, p is in (0, 100].
+// It returns false if p is out of range or the skip list is empty
+func (sl *TypedSkipList[K, S, D]) GetScoreAtPercentile(p float64) (S, bool) {
+	var score S
+
+	x := sl.getNodeByRank(percentilePosition(p, sl.length))
+	if x == nil {
+		return score, false
+	}
+
+	return x.element.Score, true
+}
+
+// GetPercentile gets the top percentile of a member, in (0, 100], e.g. 3 means the member is in the top 3%.
+// It is computed from the rank in the ranking mode
+func (lb *TypedLeaderboard[D]) GetPercentile(member string) (float64, error) {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	element := lb.skipList.GetElementByMember(member)
+	if element == nil {
+		return 0, ErrMemberNotFound
+	}
+
+	return float64(lb.rank(element)) / float64(lb.skipList.Len()) * 100, nil
+}
+
+// GetMemberAtPercentile gets the last member within the top p percent, p is in (0, 100]
+func (lb *TypedLeaderboard[D]) GetMemberAtPercentile(p float64) (*TypedRankData[D], error) {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	if math.IsNaN(p) || p <= 0 || p > 100 {
+		return nil, ErrInvalidPercentile
+	}
+
+	position := percentilePosition(p, lb.skipList.Len())
+	element := lb.skipList.GetByRank(position)
+	if element == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	return &TypedRankData[D]{
+		Rank:            lb.rankAt(element, position),
+		TypedMemberData: element.Data,
+	}, nil
+}
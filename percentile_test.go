@@ -0,0 +1,112 @@
+package rank
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSkipListGetScoreAtPercentile(t *testing.T) {
+	sl := NewSkipList()
+
+	if _, ok := sl.GetScoreAtPercentile(50); ok {
+		t.Error("Expected no score in an empty skip list")
+	}
+
+	// Insert 100 elements with scores 1 to 100, scores are sorted in descending order
+	for i := 1; i <= 100; i++ {
+		sl.Insert("key"+string(rune(i)), int64(i), nil)
+	}
+
+	tests := []struct {
+		p     float64
+		score int64
+		ok    bool
+	}{
+		{1, 100, true},
+		{3, 98, true},
+		{50, 51, true},
+		{100, 1, true},
+		{0.1, 100, true},
+		{0, 0, false},
+		{101, 0, false},
+		{math.NaN(), 0, false},
+	}
+
+	for _, tt := range tests {
+		score, ok := sl.GetScoreAtPercentile(tt.p)
+		if ok != tt.ok || score != tt.score {
+			t.Errorf("Expected score %d (%v) at percentile %v, got %d (%v)", tt.score, tt.ok, tt.p, score, ok)
+		}
+	}
+}
+
+func TestLeaderboardPercentile(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "percentile",
+		Name:         "Percentile Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	if _, err := lb.GetMemberAtPercentile(10); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound in an empty leaderboard, got %v", err)
+	}
+
+	// Add 200 members with scores 1 to 200
+	for i := 1; i <= 200; i++ {
+		lb.Add("player"+string(rune(i)), int64(i), nil)
+	}
+
+	// Test getting a member's percentile
+	percentile, err := lb.GetPercentile("player" + string(rune(195)))
+	if err != nil {
+		t.Fatalf("Failed to get percentile: %v", err)
+	}
+
+	if percentile != 3 {
+		t.Errorf("Expected top 3%%, got %v", percentile)
+	}
+
+	if _, err := lb.GetPercentile("missing"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound, got %v", err)
+	}
+
+	// Test getting the member at a percentile
+	rankData, err := lb.GetMemberAtPercentile(3)
+	if err != nil {
+		t.Fatalf("Failed to get member at percentile: %v", err)
+	}
+
+	if rankData.Rank != 6 || rankData.Score != 195 {
+		t.Errorf("Expected rank 6 with score 195 at top 3%%, got rank %d with score %d", rankData.Rank, rankData.Score)
+	}
+
+	rankData, _ = lb.GetMemberAtPercentile(100)
+	if rankData.Rank != 200 || rankData.Score != 1 {
+		t.Errorf("Expected the last member at top 100%%, got rank %d with score %d", rankData.Rank, rankData.Score)
+	}
+
+	if _, err := lb.GetMemberAtPercentile(0); !errors.Is(err, ErrInvalidPercentile) {
+		t.Errorf("Expected ErrInvalidPercentile, got %v", err)
+	}
+
+	// Test percentiles follow the ranking mode
+	tied := NewLeaderboard(LeaderboardConfig{
+		ID:           "percentile_tied",
+		Name:         "Tied Percentile Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingStandard,
+	})
+
+	tied.Add("a", 100, nil)
+	tied.Add("b", 100, nil)
+	tied.Add("c", 100, nil)
+	tied.Add("d", 50, nil)
+
+	percentile, _ = tied.GetPercentile("c")
+	if percentile != 25 {
+		t.Errorf("Expected top 25%% for a tied member, got %v", percentile)
+	}
+}
@@ -0,0 +1,191 @@
+package rank
+
+// RankingMode ranking method for members with the same score
+type RankingMode int
+
+const (
+	// RankingOrdinal every member gets a distinct rank, members with the same score are ordered by the tie-break strategy ("1234")
+	RankingOrdinal RankingMode = iota
+	// RankingStandard standard competition ranking, members with the same score share the best rank,
+	// and a gap is left after them ("1224")
+	RankingStandard
+	// RankingDense dense ranking, members with the same score share the best rank, and no gap is left ("1223")
+	RankingDense
+	// RankingModified modified competition ranking, members with the same score share the worst rank,
+	// and a gap is left before them ("1334")
+	RankingModified
+)
+
+// rank gets the rank of an element in the ranking mode, the caller must hold the lock
+func (lb *TypedLeaderboard[D]) rank(element *TypedElement[string, int64, TypedMemberData[D]]) int64 {
+	switch lb.config.RankingMode {
+	case RankingStandard:
+		return int64(lb.skipList.countBefore(element.Score, false)) + 1
+	case RankingDense:
+		return lb.scores.GetRank(element.Score, element.Score)
+	case RankingModified:
+		return int64(lb.skipList.countBefore(element.Score, true))
+	default:
+		return lb.skipList.GetRank(element.Member, element.Score)
+	}
+}
+
+// rankAt gets the rank in the ranking mode of an element whose ordinal position is already known
+func (lb *TypedLeaderboard[D]) rankAt(element *TypedElement[string, int64, TypedMemberData[D]], position int64) int64 {
+	if lb.config.RankingMode == RankingOrdinal {
+		return position
+	}
+	return lb.rank(element)
+}
+
+// RankForScore gets the rank a new member with the score would occupy in the ranking mode, without inserting it.
+// In ordinal mode the new member is placed ahead of the members with the same score, use RankForMemberScore
+// to resolve ties with the tie-break strategy
+func (lb *TypedLeaderboard[D]) RankForScore(score int64) int64 {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	return lb.rankForScore(score, nil)
+}
+
+// RankForMemberScore gets the rank the member would occupy if its score were updated to the score now, without
+// updating it. Ties are resolved with the tie-break strategy, and the update policy is not checked
+func (lb *TypedLeaderboard[D]) RankForMemberScore(member string, score int64) int64 {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	probe := &TypedElement[string, int64, TypedMemberData[D]]{
+		Member: member,
+		Score:  score,
+		Data: TypedMemberData[D]{
+			Member:    member,
+			Score:     score,
+			UpdatedAt: lb.now().Round(0),
+		},
+	}
+	return lb.rankForScore(score, probe)
+}
+
+// rankForScore gets the rank in the ranking mode of a score that is not in the skip list. When probe is not nil,
+// ties are resolved against it and the current entry of the same member is left out. The caller must hold the lock
+func (lb *TypedLeaderboard[D]) rankForScore(score int64, probe *TypedElement[string, int64, TypedMemberData[D]]) int64 {
+	sl := lb.skipList
+
+	var self *TypedElement[string, int64, TypedMemberData[D]]
+	if probe != nil {
+		self = sl.GetElementByMember(probe.Member)
+	}
+
+	switch lb.config.RankingMode {
+	case RankingStandard:
+		rank := int64(sl.countBefore(score, false)) + 1
+		if self != nil && sl.compareScore(self.Score, score) < 0 {
+			rank--
+		}
+		return rank
+	case RankingDense:
+		rank := int64(lb.scores.countBefore(score, false)) + 1
+		// The current score of the member no longer counts if no one else has it
+		if self != nil && sl.compareScore(self.Score, score) < 0 && lb.scores.GetElementByMember(self.Score).Data == 1 {
+			rank--
+		}
+		return rank
+	case RankingModified:
+		rank := int64(sl.countBefore(score, true)) + 1
+		if self != nil && sl.compareScore(self.Score, score) <= 0 {
+			rank--
+		}
+		return rank
+	default:
+		if probe == nil {
+			return int64(sl.countBefore(score, false)) + 1
+		}
+
+		rank := int64(sl.countLess(probe)) + 1
+		if self != nil && sl.compare(self, probe) < 0 {
+			rank--
+		}
+		return rank
+	}
+}
+
+// ranker derives the ranks of consecutive elements while walking the skip list,
+// so that only the first element of a group of equal scores may need a lookup
+type ranker[D any] struct {
+	lb       *TypedLeaderboard[D]
+	backward bool  // whether the walk is in reverse rank order
+	started  bool  // whether an element has been ranked
+	score    int64 // score of the previous element
+	rank     int64 // rank of the previous element
+}
+
+// next gets the rank of the element at the given ordinal position, elements must be visited consecutively
+func (r *ranker[D]) next(position int64, element *TypedElement[string, int64, TypedMemberData[D]]) int64 {
+	mode := r.lb.config.RankingMode
+	if mode == RankingOrdinal {
+		return position
+	}
+
+	// Members with the same score share the same rank
+	if r.started && element.Score == r.score {
+		return r.rank
+	}
+
+	switch {
+	case !r.started:
+		r.rank = r.lb.rank(element)
+	case mode == RankingDense && !r.backward:
+		r.rank++
+	case mode == RankingDense && r.backward:
+		r.rank--
+	case mode == RankingStandard && !r.backward:
+		// The first member of a group has the best rank of the group
+		r.rank = position
+	case mode == RankingModified && r.backward:
+		// The last member of a group has the worst rank of the group
+		r.rank = position
+	default:
+		r.rank = r.lb.rank(element)
+	}
+
+	r.started = true
+	r.score = element.Score
+	return r.rank
+}
+
+// newScoreCounter creates the distinct score list used for dense ranking, nil for other ranking modes
+func newScoreCounter(config LeaderboardConfig) *TypedSkipList[int64, int64, int] {
+	if config.RankingMode != RankingDense {
+		return nil
+	}
+	return NewSkipListWithConfig(SkipListConfig[int64, int64, int]{
+		Ascending: !config.ScoreOrder,
+	})
+}
+
+// trackScore counts a member with the score in the distinct score list
+func (lb *TypedLeaderboard[D]) trackScore(score int64) {
+	if lb.scores == nil {
+		return
+	}
+
+	if element := lb.scores.mutable(score); element != nil {
+		element.Data++
+		return
+	}
+	lb.scores.Insert(score, score, 1)
+}
+
+// untrackScore stops counting a member with the score in the distinct score list
+func (lb *TypedLeaderboard[D]) untrackScore(score int64) {
+	if lb.scores == nil {
+		return
+	}
+
+	if element := lb.scores.mutable(score); element != nil {
+		element.Data--
+		if element.Data <= 0 {
+			lb.scores.Delete(score, score)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package rank
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeaderboardRankingModes(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  RankingMode
+		ranks []int64
+	}{
+		{"Ordinal", RankingOrdinal, []int64{1, 2, 3, 4, 5, 6}},
+		{"Standard", RankingStandard, []int64{1, 2, 2, 4, 4, 6}},
+		{"Dense", RankingDense, []int64{1, 2, 2, 3, 3, 4}},
+		{"Modified", RankingModified, []int64{1, 3, 3, 5, 5, 6}},
+	}
+
+	members := []string{"a", "b", "c", "d", "e", "f"}
+	scores := []int64{100, 90, 90, 80, 80, 70}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := NewLeaderboard(LeaderboardConfig{
+				ID:           "ranking_mode",
+				Name:         "Ranking Mode Test",
+				ScoreOrder:   true,
+				UpdatePolicy: UpdateAlways,
+				RankingMode:  tt.mode,
+			})
+
+			for i, member := range members {
+				result, _ := lb.Add(member, scores[i], nil)
+				if result.Rank < 1 {
+					t.Errorf("Expected a rank for %s, got %d", member, result.Rank)
+				}
+			}
+
+			// Test GetRank
+			for i, member := range members {
+				rank, _ := lb.GetRank(member)
+				if rank != tt.ranks[i] {
+					t.Errorf("Expected rank %d for %s, got %d", tt.ranks[i], member, rank)
+				}
+			}
+
+			// Test GetRankList, including pages starting in the middle of a tie
+			for start := int64(1); start <= 6; start++ {
+				rankList, _ := lb.GetRankList(start, 6)
+				for i, item := range rankList {
+					expected := tt.ranks[start-1+int64(i)]
+					if item.Rank != expected {
+						t.Errorf("Expected rank %d for %s in page from %d, got %d", expected, item.Member, start, item.Rank)
+					}
+				}
+			}
+
+			// Test GetAroundMember
+			aroundList, _ := lb.GetAroundMember("c", 1)
+			if len(aroundList) != 3 || aroundList[0].Rank != tt.ranks[1] || aroundList[2].Rank != tt.ranks[3] {
+				t.Errorf("Expected ranks %v around c, got %v", tt.ranks[1:4], aroundList)
+			}
+
+			// Test iterators in both directions
+			for rank, item := range lb.All() {
+				if item.Rank != rank {
+					t.Errorf("Expected yielded rank %d to match item rank %d", rank, item.Rank)
+				}
+			}
+
+			i := len(members) - 1
+			for rank, item := range lb.Backward() {
+				if rank != tt.ranks[i] || item.Member != members[i] {
+					t.Errorf("Expected rank %d for %s backward, got %d for %s", tt.ranks[i], members[i], rank, item.Member)
+				}
+				i--
+			}
+
+			for rank, item := range lb.FromRank(3) {
+				if rank != tt.ranks[item.Member[0]-'a'] {
+					t.Errorf("Expected rank %d for %s from rank 3, got %d", tt.ranks[item.Member[0]-'a'], item.Member, rank)
+				}
+			}
+
+			// Test batch reads
+			result := lb.GetMembersAndRanks([]string{"e"})
+			if result["e"].Rank != tt.ranks[4] {
+				t.Errorf("Expected rank %d for e, got %d", tt.ranks[4], result["e"].Rank)
+			}
+
+			// Test Add results, f joins the tie at 80
+			addResult, _ := lb.Add("f", 80, nil)
+			if addResult.PrevRank != tt.ranks[5] {
+				t.Errorf("Expected previous rank %d for f, got %d", tt.ranks[5], addResult.PrevRank)
+			}
+
+			expected := map[RankingMode]int64{RankingOrdinal: 6, RankingStandard: 4, RankingDense: 3, RankingModified: 6}
+			if addResult.Rank != expected[tt.mode] {
+				t.Errorf("Expected rank %d for f, got %d", expected[tt.mode], addResult.Rank)
+			}
+		})
+	}
+}
+
+func TestLeaderboardDenseRankingUpdates(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "dense",
+		Name:         "Dense Ranking Test",
+		ScoreOrder:   false,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingDense,
+	})
+
+	lb.Add("a", 10, nil)
+	lb.Add("b", 20, nil)
+	lb.Add("c", 20, nil)
+	lb.Add("d", 30, nil)
+
+	rank, _ := lb.GetRank("d")
+	if rank != 3 {
+		t.Errorf("Expected dense rank 3 for d, got %d", rank)
+	}
+
+	// Moving b out of the tie keeps the distinct score of c
+	lb.Add("b", 5, nil)
+	rank, _ = lb.GetRank("d")
+	if rank != 4 {
+		t.Errorf("Expected dense rank 4 for d after update, got %d", rank)
+	}
+
+	// Removing the last member with a score removes the distinct score
+	lb.Remove("c")
+	rank, _ = lb.GetRank("d")
+	if rank != 3 {
+		t.Errorf("Expected dense rank 3 for d after removal, got %d", rank)
+	}
+
+	lb.RemoveMany([]string{"a", "b"})
+	rank, _ = lb.GetRank("d")
+	if rank != 1 {
+		t.Errorf("Expected dense rank 1 for d after batch removal, got %d", rank)
+	}
+
+	lb.Reset()
+	lb.Add("e", 50, nil)
+	rank, _ = lb.GetRank("e")
+	if rank != 1 {
+		t.Errorf("Expected dense rank 1 for e after reset, got %d", rank)
+	}
+}
+
+func TestLeaderboardRankForScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  RankingMode
+		ranks []int64
+	}{
+		{"Ordinal", RankingOrdinal, []int64{1, 2, 2, 4, 6, 7}},
+		{"Standard", RankingStandard, []int64{1, 2, 2, 4, 6, 7}},
+		{"Dense", RankingDense, []int64{1, 2, 2, 3, 4, 5}},
+		{"Modified", RankingModified, []int64{1, 2, 4, 4, 6, 7}},
+	}
+
+	scores := []int64{110, 95, 90, 85, 75, 60}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lb := NewLeaderboard(LeaderboardConfig{
+				ID:           "rank_for_score",
+				Name:         "Rank For Score Test",
+				ScoreOrder:   true,
+				UpdatePolicy: UpdateAlways,
+				RankingMode:  tt.mode,
+			})
+
+			// Scores 100, 90, 90, 80, 80, 70
+			lb.Add("a", 100, nil)
+			lb.Add("b", 90, nil)
+			lb.Add("c", 90, nil)
+			lb.Add("d", 80, nil)
+			lb.Add("e", 80, nil)
+			lb.Add("f", 70, nil)
+
+			for i, score := range scores {
+				if rank := lb.RankForScore(score); rank != tt.ranks[i] {
+					t.Errorf("Expected score %d to rank %d, got %d", score, tt.ranks[i], rank)
+				}
+			}
+
+			if lb.GetTotal() != 6 {
+				t.Errorf("Expected RankForScore not to insert anything, got %d members", lb.GetTotal())
+			}
+		})
+	}
+}
+
+func TestLeaderboardRankForMemberScore(t *testing.T) {
+	probes := []struct {
+		member string
+		score  int64
+	}{
+		{"new", 90},
+		{"new", 100},
+		{"new", 60},
+		{"b", 80},
+		{"a", 90},
+		{"f", 90},
+		{"d", 80},
+		{"c", 100},
+	}
+
+	for _, mode := range []RankingMode{RankingOrdinal, RankingStandard, RankingDense, RankingModified} {
+		for _, tieBreak := range []TieBreak{TieBreakMemberAsc, TieBreakMemberDesc, TieBreakEarliest, TieBreakLatest} {
+			for _, probe := range probes {
+				lb := NewLeaderboard(LeaderboardConfig{
+					ID:           "rank_for_member_score",
+					Name:         "Rank For Member Score Test",
+					ScoreOrder:   true,
+					UpdatePolicy: UpdateAlways,
+					TieBreak:     tieBreak,
+					RankingMode:  mode,
+				})
+
+				// Use a fake clock so that every write happens at a distinct time
+				now := time.Unix(1700000000, 0)
+				lb.now = func() time.Time {
+					now = now.Add(time.Second)
+					return now
+				}
+
+				lb.Add("a", 100, nil)
+				lb.Add("b", 90, nil)
+				lb.Add("c", 90, nil)
+				lb.Add("d", 80, nil)
+				lb.Add("e", 80, nil)
+				lb.Add("f", 70, nil)
+
+				// The preview must match the rank actually given by the update
+				expected := lb.RankForMemberScore(probe.member, probe.score)
+				result, _ := lb.Add(probe.member, probe.score, nil)
+				if result.Rank != expected {
+					t.Errorf("Expected %s with score %d to rank %d (mode %d, tie-break %d), got %d",
+						probe.member, probe.score, expected, mode, tieBreak, result.Rank)
+				}
+			}
+		}
+	}
+}
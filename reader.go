@@ -0,0 +1,46 @@
+package rank
+
+import "iter"
+
+// Reader read-only access to a leaderboard
+type Reader[D any] interface {
+	// GetRank gets a member's rank
+	GetRank(member string) (int64, error)
+	// GetMember gets a member's data
+	GetMember(member string) (*TypedMemberData[D], error)
+	// GetMemberAndRank gets a member's data and rank
+	GetMemberAndRank(member string) (*TypedRankData[D], error)
+	// GetMembersAndRanks gets the data and rank of a batch of members
+	GetMembersAndRanks(members []string) map[string]*TypedRankData[D]
+	// GetRankList gets a list of rankings within a specified range
+	GetRankList(start, end int64) ([]*TypedRankData[D], error)
+	// GetAroundMember gets a list of rankings around a specified member
+	GetAroundMember(member string, count int64) ([]*TypedRankData[D], error)
+	// GetByScoreRange gets the members within a score range
+	GetByScoreRange(min, max int64, opts ScoreRangeOptions) ([]*TypedRankData[D], error)
+	// CountByScore counts the members within a score range
+	CountByScore(min, max int64) uint64
+	// GetPercentile gets the top percentile of a member
+	GetPercentile(member string) (float64, error)
+	// GetMemberAtPercentile gets the last member within the top p percent
+	GetMemberAtPercentile(p float64) (*TypedRankData[D], error)
+	// RankForScore gets the rank a new member with the score would occupy
+	RankForScore(score int64) int64
+	// RankForMemberScore gets the rank the member would occupy with the score
+	RankForMemberScore(member string, score int64) int64
+	// GetTotal gets the total number of members
+	GetTotal() uint64
+	// All iterates over all members in rank order
+	All() iter.Seq2[int64, *TypedRankData[D]]
+	// Backward iterates over all members in reverse rank order
+	Backward() iter.Seq2[int64, *TypedRankData[D]]
+	// FromRank iterates over the members starting from a rank
+	FromRank(rank int64) iter.Seq2[int64, *TypedRankData[D]]
+	// ScoreBetween iterates over the members within a score range
+	ScoreBetween(min, max int64) iter.Seq2[int64, *TypedRankData[D]]
+	// ComputeTiers gets the members of every reward tier
+	ComputeTiers(specs []TierSpec) ([]Tier[D], error)
+}
+
+// Leaderboard implements Reader
+var _ Reader[interface{}] = (*Leaderboard)(nil)
@@ -0,0 +1,108 @@
+package rank
+
+// ScoreRangeOptions options for score range queries
+type ScoreRangeOptions struct {
+	// MinExclusive whether members with exactly the min score are excluded
+	MinExclusive bool
+	// MaxExclusive whether members with exactly the max score are excluded
+	MaxExclusive bool
+	// Offset number of members in the range to skip
+	Offset int64
+	// Limit maximum number of members to return, 0 for no limit
+	Limit int64
+	// Reverse whether to return members in reverse rank order, Offset and Limit then apply from the end of the range
+	Reverse bool
+}
+
+// scoreRangePositions gets the first and last ordinal positions of the elements within a score range,
+// start is greater than end when the range is empty
+func (sl *TypedSkipList[K, S, D]) scoreRangePositions(min, max S, minExclusive, maxExclusive bool) (start, end int64) {
+	if min > max {
+		return 1, 0
+	}
+
+	first, last := sl.scoreBounds(min, max)
+	firstExclusive, lastExclusive := maxExclusive, minExclusive
+	if sl.ascending {
+		firstExclusive, lastExclusive = minExclusive, maxExclusive
+	}
+
+	// Elements before the range are ranked before the first bound, or equal to it when it is excluded,
+	// and elements up to the end of the range are ranked before the last bound, or equal to it when it is included
+	start = int64(sl.countBefore(first, firstExclusive)) + 1
+	end = int64(sl.countBefore(last, !lastExclusive))
+	return start, end
+}
+
+// CountInScoreRange counts the elements within a score range in O(log n), bounds are excluded when the
+// corresponding flag is true
+func (sl *TypedSkipList[K, S, D]) CountInScoreRange(min, max S, minExclusive, maxExclusive bool) uint64 {
+	start, end := sl.scoreRangePositions(min, max, minExclusive, maxExclusive)
+	if start > end {
+		return 0
+	}
+	return uint64(end - start + 1)
+}
+
+// CountByScore counts the members within a score range (inclusive) in O(log n)
+func (lb *TypedLeaderboard[D]) CountByScore(min, max int64) uint64 {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	return lb.skipList.CountInScoreRange(min, max, false, false)
+}
+
+// GetByScoreRange gets the members within a score range, in rank order unless Reverse is set.
+// The range boundaries are located in O(log n), regardless of the offset
+func (lb *TypedLeaderboard[D]) GetByScoreRange(min, max int64, opts ScoreRangeOptions) ([]*TypedRankData[D], error) {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	start, end := lb.skipList.scoreRangePositions(min, max, opts.MinExclusive, opts.MaxExclusive)
+
+	// Apply offset and limit from the start of the range, or from the end when reversed. They are checked
+	// against the size of the range first, so that large values can't overflow the positions
+	offset := opts.Offset
+	if offset < 0 {
+		offset = 0
+	}
+	if start > end || offset > end-start {
+		return []*TypedRankData[D]{}, nil
+	}
+	if opts.Reverse {
+		end -= offset
+		if opts.Limit > 0 && opts.Limit <= end-start {
+			start = end - opts.Limit + 1
+		}
+	} else {
+		start += offset
+		if opts.Limit > 0 && opts.Limit <= end-start {
+			end = start + opts.Limit - 1
+		}
+	}
+
+	result := make([]*TypedRankData[D], 0, end-start+1)
+	r := ranker[D]{lb: lb, backward: opts.Reverse}
+
+	if !opts.Reverse {
+		x := lb.skipList.getNodeByRank(start)
+		for position := start; position <= end && x != nil; position++ {
+			result = append(result, &TypedRankData[D]{
+				Rank:            r.next(position, &x.element),
+				TypedMemberData: x.element.Data,
+			})
+			x = lb.skipList.next(x, 0).forward
+		}
+		return result, nil
+	}
+
+	x := lb.skipList.getNodeByRank(end)
+	for position := end; position >= start && x != nil; position-- {
+		result = append(result, &TypedRankData[D]{
+			Rank:            r.next(position, &x.element),
+			TypedMemberData: x.element.Data,
+		})
+		x = lb.skipList.links(x).backward
+	}
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package rank
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSkipListCountInScoreRange(t *testing.T) {
+	for _, ascending := range []bool{false, true} {
+		sl := NewSkipListWithConfig(SkipListConfig[string, int64, interface{}]{Ascending: ascending})
+
+		// Scores 10, 20, 20, 30, 40
+		sl.Insert("a", 10, nil)
+		sl.Insert("b", 20, nil)
+		sl.Insert("c", 20, nil)
+		sl.Insert("d", 30, nil)
+		sl.Insert("e", 40, nil)
+
+		tests := []struct {
+			min, max                   int64
+			minExclusive, maxExclusive bool
+			count                      uint64
+		}{
+			{10, 40, false, false, 5},
+			{20, 30, false, false, 3},
+			{20, 30, true, false, 1},
+			{20, 30, false, true, 2},
+			{20, 20, false, false, 2},
+			{20, 20, true, false, 0},
+			{0, 5, false, false, 0},
+			{40, 10, false, false, 0},
+			{-100, 100, true, true, 5},
+		}
+
+		for _, tt := range tests {
+			count := sl.CountInScoreRange(tt.min, tt.max, tt.minExclusive, tt.maxExclusive)
+			if count != tt.count {
+				t.Errorf("Expected %d elements in [%d, %d] (exclusive %v %v, ascending %v), got %d",
+					tt.count, tt.min, tt.max, tt.minExclusive, tt.maxExclusive, ascending, count)
+			}
+		}
+	}
+}
+
+func TestLeaderboardGetByScoreRange(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "score_range",
+		Name:         "Score Range Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	// player1..player10 with scores 100..1000, player10 ranks first
+	for i := 1; i <= 10; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i*100), nil)
+	}
+
+	if count := lb.CountByScore(300, 700); count != 5 {
+		t.Errorf("Expected 5 members between 300 and 700, got %d", count)
+	}
+
+	tests := []struct {
+		name    string
+		opts    ScoreRangeOptions
+		members []string
+		ranks   []int64
+	}{
+		{"inclusive", ScoreRangeOptions{}, []string{"player7", "player6", "player5", "player4", "player3"}, []int64{4, 5, 6, 7, 8}},
+		{"exclusive", ScoreRangeOptions{MinExclusive: true, MaxExclusive: true}, []string{"player6", "player5", "player4"}, []int64{5, 6, 7}},
+		{"offset limit", ScoreRangeOptions{Offset: 1, Limit: 2}, []string{"player6", "player5"}, []int64{5, 6}},
+		{"reverse", ScoreRangeOptions{Reverse: true, Limit: 2}, []string{"player3", "player4"}, []int64{8, 7}},
+		{"reverse offset", ScoreRangeOptions{Reverse: true, Offset: 3, Limit: 5}, []string{"player6", "player7"}, []int64{5, 4}},
+		{"offset past end", ScoreRangeOptions{Offset: 5}, []string{}, []int64{}},
+		{"huge offset", ScoreRangeOptions{Offset: math.MaxInt64}, []string{}, []int64{}},
+		{"reverse huge offset", ScoreRangeOptions{Reverse: true, Offset: math.MaxInt64}, []string{}, []int64{}},
+		{"huge limit", ScoreRangeOptions{Offset: 3, Limit: math.MaxInt64}, []string{"player4", "player3"}, []int64{7, 8}},
+		{"reverse huge limit", ScoreRangeOptions{Reverse: true, Offset: 3, Limit: math.MaxInt64}, []string{"player6", "player7"}, []int64{5, 4}},
+	}
+
+	for _, tt := range tests {
+		result, err := lb.GetByScoreRange(300, 700, tt.opts)
+		if err != nil {
+			t.Fatalf("%s: failed to get score range: %v", tt.name, err)
+		}
+
+		if len(result) != len(tt.members) {
+			t.Errorf("%s: expected %d members, got %d", tt.name, len(tt.members), len(result))
+			continue
+		}
+
+		for i, item := range result {
+			if item.Member != tt.members[i] || item.Rank != tt.ranks[i] {
+				t.Errorf("%s: expected %s at rank %d, got %s at rank %d", tt.name, tt.members[i], tt.ranks[i], item.Member, item.Rank)
+			}
+		}
+	}
+}
+
+func TestLeaderboardGetByScoreRangeAscending(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "score_range_asc",
+		Name:         "Score Range Ascending Test",
+		ScoreOrder:   false,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingStandard,
+	})
+
+	lb.Add("racer1", 100, nil)
+	lb.Add("racer2", 120, nil)
+	lb.Add("racer3", 120, nil)
+	lb.Add("racer4", 150, nil)
+
+	result, _ := lb.GetByScoreRange(110, 200, ScoreRangeOptions{MaxExclusive: true})
+	expected := []struct {
+		member string
+		rank   int64
+	}{
+		{"racer2", 2},
+		{"racer3", 2},
+		{"racer4", 4},
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(result))
+	}
+	for i, item := range result {
+		if item.Member != expected[i].member || item.Rank != expected[i].rank {
+			t.Errorf("Expected %s at rank %d, got %s at rank %d", expected[i].member, expected[i].rank, item.Member, item.Rank)
+		}
+	}
+
+	if count := lb.CountByScore(120, 150); count != 3 {
+		t.Errorf("Expected 3 members between 120 and 150, got %d", count)
+	}
+}
@@ -0,0 +1,309 @@
+package rank
+
+import (
+	"sync"
+	"time"
+)
+
+// Period length of the seasons of a seasonal leaderboard
+type Period int
+
+const (
+	// PeriodDaily seasons start every day at midnight
+	PeriodDaily Period = iota
+	// PeriodWeekly seasons start every week at midnight of WeekStart
+	PeriodWeekly
+	// PeriodMonthly seasons start at midnight of the first day of every month
+	PeriodMonthly
+	// PeriodCustom seasons last Duration, counted from Anchor
+	PeriodCustom
+)
+
+// SeasonConfig seasonal leaderboard configuration
+type SeasonConfig struct {
+	// Leaderboard configuration of the leaderboard of every season
+	Leaderboard LeaderboardConfig
+	// Period length of the seasons
+	Period Period
+	// Location time zone of the season boundaries, UTC if nil
+	Location *time.Location
+	// WeekStart first day of weekly seasons
+	WeekStart time.Weekday
+	// Duration length of custom seasons, one day if not positive
+	Duration time.Duration
+	// Anchor start of a custom season, the other custom seasons are counted from it, the Unix epoch if zero
+	Anchor time.Time
+	// History number of previous seasons kept read-only
+	History int
+}
+
+// Season a period of a seasonal leaderboard
+type Season[D any] struct {
+	// ID season identifier, the start date for daily and weekly seasons ("2006-01-02"), the month for monthly
+	// seasons ("2006-01"), and the start time for custom seasons (RFC 3339)
+	ID string
+	// Start start of the season, inclusive
+	Start time.Time
+	// End end of the season, exclusive
+	End time.Time
+	// Leaderboard scores of the season
+	Leaderboard Reader[D]
+}
+
+// SeasonalLeaderboard leaderboard whose scores are bucketed into seasons, rolling over to a new empty leaderboard
+// at every season boundary, while the previous seasons stay queryable read-only
+type SeasonalLeaderboard[D any] struct {
+	// config configuration information
+	config SeasonConfig
+	// current current season
+	current season[D]
+	// history previous seasons, newest first
+	history []season[D]
+	// mutex mutex for thread safety
+	mutex sync.RWMutex
+	// now returns the current time, used for the season boundaries
+	now func() time.Time
+}
+
+// season a season with its writable leaderboard
+type season[D any] struct {
+	id          string
+	start, end  time.Time
+	leaderboard *TypedLeaderboard[D]
+}
+
+// NewSeasonalLeaderboard creates a new seasonal leaderboard with untyped additional data
+func NewSeasonalLeaderboard(config SeasonConfig) *SeasonalLeaderboard[interface{}] {
+	return NewTypedSeasonalLeaderboard[interface{}](config)
+}
+
+// NewTypedSeasonalLeaderboard creates a new seasonal leaderboard whose additional data is of type D
+func NewTypedSeasonalLeaderboard[D any](config SeasonConfig) *SeasonalLeaderboard[D] {
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+	if config.Duration <= 0 {
+		config.Duration = 24 * time.Hour
+	}
+
+	s := &SeasonalLeaderboard[D]{
+		config: config,
+		mutex:  sync.RWMutex{},
+		now:    time.Now,
+	}
+	s.current = s.newSeason(s.now())
+
+	return s
+}
+
+// Add adds or updates a member's score in the current season
+func (s *SeasonalLeaderboard[D]) Add(member string, score int64, data D) (*AddResult[D], error) {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.current.leaderboard.Add(member, score, data)
+}
+
+// Incr atomically adds delta to a member's score in the current season
+func (s *SeasonalLeaderboard[D]) Incr(member string, delta int64, data D) (*AddResult[D], error) {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.current.leaderboard.Incr(member, delta, data)
+}
+
+// Remove removes a member from the current season, errors are reported like Leaderboard.Remove
+func (s *SeasonalLeaderboard[D]) Remove(member string) (bool, error) {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.current.leaderboard.Remove(member)
+}
+
+// Current gets the leaderboard of the current season. It stops receiving the writes of the seasonal leaderboard
+// and is frozen at the end of the season
+func (s *SeasonalLeaderboard[D]) Current() *TypedLeaderboard[D] {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.current.leaderboard
+}
+
+// CurrentSeason gets the current season
+func (s *SeasonalLeaderboard[D]) CurrentSeason() Season[D] {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.current.export()
+}
+
+// CurrentPeriod gets the ID of the current season
+func (s *SeasonalLeaderboard[D]) CurrentPeriod() string {
+	return s.CurrentSeason().ID
+}
+
+// History gets the previous seasons kept read-only, newest first. Seasons without any write are not kept
+func (s *SeasonalLeaderboard[D]) History() []Season[D] {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	seasons := make([]Season[D], 0, len(s.history))
+	for _, previous := range s.history {
+		seasons = append(seasons, previous.export())
+	}
+
+	return seasons
+}
+
+// GetSeason gets the current season or a previous season kept read-only by ID
+func (s *SeasonalLeaderboard[D]) GetSeason(id string) (Season[D], bool) {
+	s.rollover()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.current.id == id {
+		return s.current.export(), true
+	}
+
+	for _, previous := range s.history {
+		if previous.id == id {
+			return previous.export(), true
+		}
+	}
+
+	return Season[D]{}, false
+}
+
+// rollover starts a new season if the current one is over, the write lock is only taken at season boundaries
+func (s *SeasonalLeaderboard[D]) rollover() {
+	s.mutex.RLock()
+	now := s.now()
+	over := !now.Before(s.current.end)
+	s.mutex.RUnlock()
+
+	if !over {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Check again, another goroutine may have rolled over in the meantime
+	now = s.now()
+	if now.Before(s.current.end) {
+		return
+	}
+
+	// Keep the season that is over if it has been written to
+	s.current.leaderboard.archive(s.current.end)
+	if s.config.History > 0 && s.current.leaderboard.GetTotal() > 0 {
+		s.history = append([]season[D]{s.current}, s.history...)
+		if len(s.history) > s.config.History {
+			clear(s.history[s.config.History:])
+			s.history = s.history[:s.config.History]
+		}
+	}
+
+	s.current = s.newSeason(now)
+}
+
+// newSeason creates the season containing the given time, with an empty leaderboard
+func (s *SeasonalLeaderboard[D]) newSeason(t time.Time) season[D] {
+	start, end := s.bounds(t)
+
+	leaderboard := NewTypedLeaderboard[D](s.config.Leaderboard)
+	leaderboard.now = func() time.Time {
+		return s.now()
+	}
+
+	return season[D]{
+		id:          s.seasonID(start),
+		start:       start,
+		end:         end,
+		leaderboard: leaderboard,
+	}
+}
+
+// bounds gets the start and end of the season containing the given time
+func (s *SeasonalLeaderboard[D]) bounds(t time.Time) (start, end time.Time) {
+	t = t.In(s.config.Location)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.config.Location)
+
+	switch s.config.Period {
+	case PeriodWeekly:
+		days := (int(t.Weekday()) - int(s.config.WeekStart) + 7) % 7
+		start = midnight.AddDate(0, 0, -days)
+		return start, start.AddDate(0, 0, 7)
+	case PeriodMonthly:
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.config.Location)
+		return start, start.AddDate(0, 1, 0)
+	case PeriodCustom:
+		anchor := s.config.Anchor
+		if anchor.IsZero() {
+			anchor = time.Unix(0, 0)
+		}
+		anchor = anchor.In(s.config.Location)
+
+		// Round down to a multiple of the duration, also for times before the anchor
+		n := t.Sub(anchor) / s.config.Duration
+		if t.Before(anchor) && t.Sub(anchor)%s.config.Duration != 0 {
+			n--
+		}
+		start = anchor.Add(n * s.config.Duration)
+		return start, start.Add(s.config.Duration)
+	default:
+		return midnight, midnight.AddDate(0, 0, 1)
+	}
+}
+
+// seasonID gets the ID of the season starting at the given time
+func (s *SeasonalLeaderboard[D]) seasonID(start time.Time) string {
+	switch s.config.Period {
+	case PeriodMonthly:
+		return start.Format("2006-01")
+	case PeriodCustom:
+		return start.Format(time.RFC3339)
+	default:
+		return start.Format("2006-01-02")
+	}
+}
+
+// export gets the public view of a season
+func (s season[D]) export() Season[D] {
+	return Season[D]{
+		ID:          s.id,
+		Start:       s.start,
+		End:         s.end,
+		Leaderboard: s.leaderboard,
+	}
+}
+
+// archive stops the expiry of the members of a leaderboard whose season is over at end, and freezes it, so that
+// it keeps the standings it had at the end of the season
+func (lb *TypedLeaderboard[D]) archive(end time.Time) {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.purgeExpiredAt(end)
+	lb.expiry = newExpiryQueue()
+	if lb.skipList.shared() {
+		lb.skipList = lb.skipList.clone()
+	}
+	for _, element := range lb.skipList.All() {
+		element.Data.ExpiresAt = time.Time{}
+	}
+	lb.frozen = true
+}
@@ -0,0 +1,195 @@
+package rank
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSeasonalLeaderboardRollover(t *testing.T) {
+	shanghai := time.FixedZone("UTC+8", 8*60*60)
+
+	s := NewSeasonalLeaderboard(SeasonConfig{
+		Leaderboard: LeaderboardConfig{
+			ID:           "daily",
+			Name:         "Daily Test",
+			ScoreOrder:   true,
+			UpdatePolicy: UpdateAlways,
+		},
+		Period:   PeriodDaily,
+		Location: shanghai,
+		History:  2,
+	})
+
+	// Use a fake clock, 23:00 on 2026-10-16 in UTC+8
+	now := time.Date(2026, 10, 16, 23, 0, 0, 0, shanghai)
+	s.now = func() time.Time {
+		return now
+	}
+	s.current = s.newSeason(now)
+
+	if id := s.CurrentPeriod(); id != "2026-10-16" {
+		t.Errorf("Expected period 2026-10-16, got %s", id)
+	}
+
+	s.Add("player1", 100, nil)
+	s.Add("player2", 200, nil)
+	first := s.Current()
+
+	// Midnight in UTC+8 is still the previous day in UTC
+	now = time.Date(2026, 10, 16, 16, 30, 0, 0, time.UTC)
+	if id := s.CurrentPeriod(); id != "2026-10-17" {
+		t.Errorf("Expected period 2026-10-17, got %s", id)
+	}
+
+	// The leaderboard of a season is frozen at its end
+	if _, err := first.Add("player3", 300, nil); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected the previous season to be frozen, got %v", err)
+	}
+
+	if total := s.Current().GetTotal(); total != 0 {
+		t.Errorf("Expected an empty leaderboard in the new season, got %d members", total)
+	}
+
+	s.Incr("player1", 10, nil)
+
+	// The previous season stays queryable
+	previous, ok := s.GetSeason("2026-10-16")
+	if !ok {
+		t.Fatal("Expected season 2026-10-16 to be kept")
+	}
+
+	if rank, _ := previous.Leaderboard.GetRank("player1"); rank != 2 {
+		t.Errorf("Expected rank 2 in the previous season, got %d", rank)
+	}
+
+	// The rewards of the previous season are computed from the archived leaderboard
+	tiers, err := previous.Leaderboard.ComputeTiers([]TierSpec{{Name: "champion", MinRank: 1}})
+	if err != nil || len(tiers[0].Members) != 1 || tiers[0].Members[0].Member != "player2" {
+		t.Errorf("Expected player2 as champion of the previous season, got %v %v", tiers, err)
+	}
+
+	if !previous.End.Equal(time.Date(2026, 10, 17, 0, 0, 0, 0, shanghai)) {
+		t.Errorf("Expected the season to end at midnight, got %v", previous.End)
+	}
+
+	// Only the last 2 seasons with writes are kept
+	for day := 18; day <= 20; day++ {
+		now = time.Date(2026, 10, day, 12, 0, 0, 0, shanghai)
+		s.Add("player3", int64(day), nil)
+	}
+
+	now = now.AddDate(0, 0, 1)
+	history := s.History()
+	if len(history) != 2 || history[0].ID != "2026-10-20" || history[1].ID != "2026-10-19" {
+		t.Errorf("Expected seasons 2026-10-20 and 2026-10-19, got %v", history)
+	}
+
+	if _, ok := s.GetSeason("2026-10-16"); ok {
+		t.Error("Expected season 2026-10-16 to be dropped")
+	}
+}
+
+func TestSeasonalLeaderboardBounds(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		newYork = time.FixedZone("UTC-5", -5*60*60)
+	}
+
+	tests := []struct {
+		name   string
+		config SeasonConfig
+		now    time.Time
+		id     string
+		start  time.Time
+		end    time.Time
+	}{
+		{
+			name:   "Weekly",
+			config: SeasonConfig{Period: PeriodWeekly, WeekStart: time.Monday},
+			now:    time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC),
+			id:     "2026-10-12",
+			start:  time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "Monthly",
+			config: SeasonConfig{Period: PeriodMonthly, Location: newYork},
+			now:    time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC),
+			id:     "2026-10",
+			start:  time.Date(2026, 10, 1, 0, 0, 0, 0, newYork),
+			end:    time.Date(2026, 11, 1, 0, 0, 0, 0, newYork),
+		},
+		{
+			name:   "Custom",
+			config: SeasonConfig{Period: PeriodCustom, Duration: 6 * time.Hour, Anchor: time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)},
+			now:    time.Date(2026, 10, 16, 2, 0, 0, 0, time.UTC),
+			id:     "2026-10-15T21:00:00Z",
+			start:  time.Date(2026, 10, 15, 21, 0, 0, 0, time.UTC),
+			end:    time.Date(2026, 10, 16, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "CustomBeforeAnchor",
+			config: SeasonConfig{Period: PeriodCustom, Duration: 6 * time.Hour, Anchor: time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)},
+			now:    time.Date(2025, 12, 31, 20, 0, 0, 0, time.UTC),
+			id:     "2025-12-31T15:00:00Z",
+			start:  time.Date(2025, 12, 31, 15, 0, 0, 0, time.UTC),
+			end:    time.Date(2025, 12, 31, 21, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSeasonalLeaderboard(tt.config)
+			s.now = func() time.Time {
+				return tt.now
+			}
+			s.current = s.newSeason(tt.now)
+
+			season := s.CurrentSeason()
+			if season.ID != tt.id || !season.Start.Equal(tt.start) || !season.End.Equal(tt.end) {
+				t.Errorf("Expected season %s from %v to %v, got %s from %v to %v",
+					tt.id, tt.start, tt.end, season.ID, season.Start, season.End)
+			}
+		})
+	}
+}
+
+func TestSeasonalLeaderboardArchivedTTL(t *testing.T) {
+	s := NewSeasonalLeaderboard(SeasonConfig{
+		Leaderboard: LeaderboardConfig{
+			ID:           "archived_ttl",
+			Name:         "Archived TTL Test",
+			ScoreOrder:   true,
+			UpdatePolicy: UpdateAlways,
+			MemberTTL:    time.Hour,
+		},
+		Period:  PeriodDaily,
+		History: 1,
+	})
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time {
+		return now
+	}
+	s.current = s.newSeason(now)
+
+	// player1 expires before the end of the season, player2 after it
+	s.Add("player1", 100, nil)
+	now = time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+	s.Add("player2", 200, nil)
+
+	now = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	previous, ok := s.GetSeason("2026-10-16")
+	if !ok {
+		t.Fatal("Expected season 2026-10-16 to be kept")
+	}
+
+	// Archived seasons keep the members they had at the end of the season
+	if total := previous.Leaderboard.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member in the archived season, got %d", total)
+	}
+	if _, err := previous.Leaderboard.GetMember("player2"); err != nil {
+		t.Errorf("Expected player2 to be kept, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package rank
 
 import (
+	"cmp"
+	"fmt"
 	"math/rand"
 	"time"
 )
@@ -14,55 +16,123 @@ const (
 
 var defaultRand = rand.New(rand.NewSource(time.Now().UnixNano()))
 
-// Element is an element stored in the skip list
-type Element struct {
+// Element an element of a skip list with string members, int64 scores and untyped data
+type Element = TypedElement[string, int64, interface{}]
+
+// TypedElement is an element stored in the skip list
+type TypedElement[K cmp.Ordered, S cmp.Ordered, D any] struct {
 	// Member is the ID or name of the member
-	Member string
+	Member K
 	// Score is used for ranking
-	Score int64
+	Score S
 	// Data is additional data that can be stored
-	Data interface{}
+	Data D
 }
 
 // node is the internal node structure
-type node struct {
-	element Element
+type node[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	element TypedElement[K, S, D]
+	// nodeLinks the current links of the node
+	nodeLinks[K, S, D]
+	// created the epoch in which the node was inserted, and removed the epoch in which it was unlinked, which is
+	// only set if views of the skip list may still see the node
+	created, removed uint64
+	// prior the unlinked node the member had before this one, only kept while views may still see it
+	prior *node[K, S, D]
+}
+
+// nodeLinks the links of a node as written in an epoch
+type nodeLinks[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	// backward points to the previous node at the lowest level, nil for the first node
+	backward *node[K, S, D]
 	// level[i] represents the next node and span at level i
-	level []*levelNode
+	level []*levelNode[K, S, D]
+	// epoch the epoch in which the links were last written
+	epoch uint64
+	// older the links the node had before epoch, only kept while views may still see them
+	older *nodeLinks[K, S, D]
 }
 
 // levelNode represents a node at a specific level in the skip list
-type levelNode struct {
-	forward *node  // points to the next node at this level
-	span    uint64 // span to the next node
+type levelNode[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	forward *node[K, S, D] // points to the next node at this level
+	span    uint64         // span to the next node
+}
+
+// Comparator breaks ties between two elements that have the same score. It returns a negative number
+// when a should be ranked before b, a positive number when a should be ranked after b, and zero to
+// fall back to sorting by member lexicographically
+type Comparator[K cmp.Ordered, S cmp.Ordered, D any] func(a, b *TypedElement[K, S, D]) int
+
+// MemberAsc is a comparator that ranks members with the same score in ascending lexicographic order,
+// which is also the default order
+func MemberAsc[K cmp.Ordered, S cmp.Ordered, D any](a, b *TypedElement[K, S, D]) int {
+	return cmp.Compare(a.Member, b.Member)
 }
 
-// SkipList implementation
-type SkipList struct {
-	head       *node            // head node, doesn't contain actual data
-	tail       *node            // tail node
-	length     uint64           // number of elements
-	level      int              // current maximum level
-	elementMap map[string]*node // mapping from member to node for fast lookup
+// MemberDesc is a comparator that ranks members with the same score in descending lexicographic order
+func MemberDesc[K cmp.Ordered, S cmp.Ordered, D any](a, b *TypedElement[K, S, D]) int {
+	return cmp.Compare(b.Member, a.Member)
 }
 
-// NewSkipList creates a new skip list
+// SkipListConfig skip list configuration
+type SkipListConfig[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	// Ascending score ordering method, false for high scores first, true for low scores first
+	Ascending bool
+	// Comparator tie-break strategy for elements with the same score, nil means MemberAsc
+	Comparator Comparator[K, S, D]
+}
+
+// SkipList skip list with string members, int64 scores and untyped data
+type SkipList = TypedSkipList[string, int64, interface{}]
+
+// TypedSkipList implementation, K is the member type, S is the score type and D is the type of the additional data
+type TypedSkipList[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	head       *node[K, S, D]       // head node, doesn't contain actual data
+	tail       *node[K, S, D]       // tail node
+	length     uint64               // number of elements
+	level      int                  // current maximum level
+	elementMap map[K]*node[K, S, D] // mapping from member to node for fast lookup
+	comparator Comparator[K, S, D]  // tie-break strategy for elements with the same score
+	ascending  bool                 // whether low scores come first
+	epoch      uint64               // epoch of the writes, or the epoch seen by a view
+	history    *history[K, S, D]    // epochs seen by the views and what is kept for them, shared with the views
+}
+
+// NewSkipList creates a new skip list with string members, int64 scores and untyped data
 func NewSkipList() *SkipList {
-	head := &node{
-		level: make([]*levelNode, MaxLevel),
+	return NewTypedSkipList[string, int64, interface{}]()
+}
+
+// NewTypedSkipList creates a new skip list with the given member, score and data types
+func NewTypedSkipList[K cmp.Ordered, S cmp.Ordered, D any]() *TypedSkipList[K, S, D] {
+	return NewSkipListWithConfig(SkipListConfig[K, S, D]{})
+}
+
+// NewSkipListWithConfig creates a new skip list with the given configuration
+func NewSkipListWithConfig[K cmp.Ordered, S cmp.Ordered, D any](config SkipListConfig[K, S, D]) *TypedSkipList[K, S, D] {
+	head := &node[K, S, D]{
+		nodeLinks: nodeLinks[K, S, D]{level: make([]*levelNode[K, S, D], MaxLevel)},
 	}
 
 	for i := 0; i < MaxLevel; i++ {
-		head.level[i] = &levelNode{
+		head.level[i] = &levelNode[K, S, D]{
 			forward: nil,
 			span:    0,
 		}
 	}
 
-	return &SkipList{
+	comparator := config.Comparator
+	if comparator == nil {
+		comparator = MemberAsc[K, S, D]
+	}
+
+	return &TypedSkipList[K, S, D]{
 		head:       head,
 		level:      1,
-		elementMap: make(map[string]*node),
+		elementMap: make(map[K]*node[K, S, D]),
+		comparator: comparator,
+		ascending:  config.Ascending,
 	}
 }
 
@@ -75,8 +145,32 @@ func randomLevel() int {
 	return level
 }
 
+// compareScore returns a negative number when score a is ranked before score b, a positive number when
+// score a is ranked after score b, and zero when they are equal
+func (sl *TypedSkipList[K, S, D]) compareScore(a, b S) int {
+	if sl.ascending {
+		return cmp.Compare(a, b)
+	}
+	return cmp.Compare(b, a)
+}
+
+// compare returns a negative number when a is ranked before b, a positive number when a is ranked after b,
+// and zero when they are ranked at the same position
+func (sl *TypedSkipList[K, S, D]) compare(a, b *TypedElement[K, S, D]) int {
+	// Scores come first in the order of the skip list
+	if c := sl.compareScore(a.Score, b.Score); c != 0 {
+		return c
+	}
+
+	// If scores are the same, use the tie-break strategy, and finally sort by member ID lexicographically
+	if c := sl.comparator(a, b); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Member, b.Member)
+}
+
 // Insert inserts an element, or updates it if it already exists
-func (sl *SkipList) Insert(member string, score int64, data interface{}) *Element {
+func (sl *TypedSkipList[K, S, D]) Insert(member K, score S, data D) *TypedElement[K, S, D] {
 	// If already exists, delete the old one first
 	if oldNode, ok := sl.elementMap[member]; ok {
 		sl.Delete(member, oldNode.element.Score)
@@ -88,24 +182,25 @@ func (sl *SkipList) Insert(member string, score int64, data interface{}) *Elemen
 		sl.level = level
 	}
 
-	newNode := &node{
-		element: Element{
+	newNode := &node[K, S, D]{
+		element: TypedElement[K, S, D]{
 			Member: member,
 			Score:  score,
 			Data:   data,
 		},
-		level: make([]*levelNode, level),
+		nodeLinks: nodeLinks[K, S, D]{level: make([]*levelNode[K, S, D], level), epoch: sl.epoch},
+		created:   sl.epoch,
 	}
 
 	for i := 0; i < level; i++ {
-		newNode.level[i] = &levelNode{
+		newNode.level[i] = &levelNode[K, S, D]{
 			forward: nil,
 			span:    0,
 		}
 	}
 
 	// Get insertion position
-	var update [MaxLevel]*node
+	var update [MaxLevel]*node[K, S, D]
 	var rank [MaxLevel]uint64
 
 	// Find position
@@ -117,17 +212,21 @@ func (sl *SkipList) Insert(member string, score int64, data interface{}) *Elemen
 			rank[i] = rank[i+1]
 		}
 
-		// Note the comparison logic: higher scores come first, if scores are the same, sort by member ID lexicographically
-		for x.level[i].forward != nil &&
-			(x.level[i].forward.element.Score > score ||
-				(x.level[i].forward.element.Score == score &&
-					x.level[i].forward.element.Member < member)) {
+		for x.level[i].forward != nil && sl.compare(&x.level[i].forward.element, &newNode.element) < 0 {
 			rank[i] += x.level[i].span
 			x = x.level[i].forward
 		}
 		update[i] = x
 	}
 
+	// Keep the links the views still see before writing them
+	for i := 0; i < sl.level; i++ {
+		sl.keep(update[i])
+	}
+	if update[0].level[0].forward != nil {
+		sl.keep(update[0].level[0].forward)
+	}
+
 	// Insert the node
 	for i := 0; i < level; i++ {
 		newNode.level[i].forward = update[i].level[i].forward
@@ -143,8 +242,13 @@ func (sl *SkipList) Insert(member string, score int64, data interface{}) *Elemen
 		update[i].level[i].span++
 	}
 
-	// Update tail pointer if this is the last node
-	if newNode.level[0].forward == nil {
+	// Update backward pointers, and the tail pointer if this is the last node
+	if update[0] != sl.head {
+		newNode.backward = update[0]
+	}
+	if newNode.level[0].forward != nil {
+		newNode.level[0].forward.backward = newNode
+	} else {
 		sl.tail = newNode
 	}
 
@@ -156,17 +260,18 @@ func (sl *SkipList) Insert(member string, score int64, data interface{}) *Elemen
 }
 
 // Delete removes an element
-func (sl *SkipList) Delete(member string, score int64) bool {
+func (sl *TypedSkipList[K, S, D]) Delete(member K, score S) bool {
+	target, ok := sl.elementMap[member]
+	if !ok || target.element.Score != score {
+		return false
+	}
+
 	// Find the node to delete
-	var update [MaxLevel]*node
+	var update [MaxLevel]*node[K, S, D]
 
 	x := sl.head
 	for i := sl.level - 1; i >= 0; i-- {
-		// Note the comparison logic: higher scores come first, if scores are the same, sort by member ID lexicographically
-		for x.level[i].forward != nil &&
-			(x.level[i].forward.element.Score > score ||
-				(x.level[i].forward.element.Score == score &&
-					x.level[i].forward.element.Member < member)) {
+		for x.level[i].forward != nil && sl.compare(&x.level[i].forward.element, &target.element) < 0 {
 			x = x.level[i].forward
 		}
 		update[i] = x
@@ -174,55 +279,201 @@ func (sl *SkipList) Delete(member string, score int64) bool {
 
 	// Find the node to be deleted
 	x = x.level[0].forward
-	if x != nil && x.element.Score == score && x.element.Member == member {
-		// Remove from all levels
-		for i := 0; i < sl.level; i++ {
-			if update[i].level[i].forward == x {
-				update[i].level[i].span += x.level[i].span - 1
-				update[i].level[i].forward = x.level[i].forward
-			} else {
-				update[i].level[i].span--
-			}
+	if x != target {
+		return false
+	}
+
+	sl.deleteNode(x, &update)
+	return true
+}
+
+// deleteNode unlinks a node, update holds the last node before it on every level
+func (sl *TypedSkipList[K, S, D]) deleteNode(x *node[K, S, D], update *[MaxLevel]*node[K, S, D]) {
+	// Keep the links the views still see before writing them
+	for i := 0; i < sl.level; i++ {
+		sl.keep(update[i])
+	}
+	if x.level[0].forward != nil {
+		sl.keep(x.level[0].forward)
+	}
+
+	// Remove from all levels
+	for i := 0; i < sl.level; i++ {
+		if update[i].level[i].forward == x {
+			update[i].level[i].span += x.level[i].span - 1
+			update[i].level[i].forward = x.level[i].forward
+		} else {
+			update[i].level[i].span--
+		}
+	}
+
+	// Update backward pointers, and the tail pointer if deleted node was the tail
+	if x.level[0].forward != nil {
+		x.level[0].forward.backward = x.backward
+	} else {
+		sl.tail = x.backward
+	}
+
+	// Update the maximum level
+	for sl.level > 1 && sl.head.level[sl.level-1].forward == nil {
+		sl.level--
+	}
+
+	// Remove from the map
+	sl.retire(x)
+	delete(sl.elementMap, x.element.Member)
+	sl.length--
+}
+
+// nodeRun is a run of consecutive nodes found by seekRankRange, which can be unlinked at once by unlinkRun
+type nodeRun[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	start    int64                    // ordinal position of the first node
+	elements []*TypedElement[K, S, D] // elements of the nodes in rank order
+	update   [MaxLevel]*node[K, S, D] // last node before the run on every level
+	rank     [MaxLevel]uint64         // ordinal position of the update nodes
+	last     [MaxLevel]*node[K, S, D] // last node of the run on every level, nil if no node of the run reaches it
+	lastRank [MaxLevel]uint64         // ordinal position of the last nodes
+}
+
+// seekRankRange finds the nodes within a rank range in a single walk, without changing the skip list
+func (sl *TypedSkipList[K, S, D]) seekRankRange(start, end int64) *nodeRun[K, S, D] {
+	if start < 1 {
+		start = 1
+	}
+	if end > int64(sl.length) {
+		end = int64(sl.length)
+	}
+
+	run := &nodeRun[K, S, D]{start: start}
+	if start > end {
+		run.elements = []*TypedElement[K, S, D]{}
+		return run
+	}
+
+	// Find the last node before the range on every level
+	x := sl.head
+	for i := sl.level - 1; i >= 0; i-- {
+		if i < sl.level-1 {
+			run.rank[i] = run.rank[i+1]
 		}
+		for x.level[i].forward != nil && run.rank[i]+x.level[i].span < uint64(start) {
+			run.rank[i] += x.level[i].span
+			x = x.level[i].forward
+		}
+		run.update[i] = x
+	}
 
-		// If deleted node was the tail
-		if x.level[0].forward == nil {
-			sl.tail = update[0]
+	// Walk the bottom level through the range, remembering the last node of the run on every level
+	run.elements = make([]*TypedElement[K, S, D], 0, end-start+1)
+	x = x.level[0].forward
+	for position := start; position <= end && x != nil; position++ {
+		for i := range x.level {
+			run.last[i] = x
+			run.lastRank[i] = uint64(position)
 		}
+		run.elements = append(run.elements, &x.element)
+		x = x.level[0].forward
+	}
+
+	return run
+}
+
+// unlinkRun removes a run found by seekRankRange, which must not have been modified since, fixing the links and
+// spans of every level once for the whole run
+func (sl *TypedSkipList[K, S, D]) unlinkRun(run *nodeRun[K, S, D]) {
+	count := uint64(len(run.elements))
+	if count == 0 {
+		return
+	}
+
+	// Keep the links the views still see before writing them
+	for i := 0; i < sl.level; i++ {
+		sl.keep(run.update[i])
+	}
+	next := run.last[0].level[0].forward
+	if next != nil {
+		sl.keep(next)
+	}
 
-		// Update the maximum level
-		for sl.level > 1 && sl.head.level[sl.level-1].forward == nil {
-			sl.level--
+	for i := 0; i < sl.level; i++ {
+		update := run.update[i].level[i]
+		if last := run.last[i]; last != nil {
+			// Skip over the run to the node after its last node on this level
+			update.span = run.lastRank[i] + last.level[i].span - run.rank[i] - count
+			update.forward = last.level[i].forward
+		} else {
+			update.span -= count
 		}
+	}
 
-		// Remove from the map
-		delete(sl.elementMap, member)
-		sl.length--
+	// Update backward pointers, and the tail pointer if the run was at the end
+	var backward *node[K, S, D]
+	if run.update[0] != sl.head {
+		backward = run.update[0]
+	}
+	if next != nil {
+		next.backward = backward
+	} else {
+		sl.tail = backward
+	}
 
-		return true
+	// Update the maximum level
+	for sl.level > 1 && sl.head.level[sl.level-1].forward == nil {
+		sl.level--
 	}
 
-	return false
+	// Remove from the map
+	for _, element := range run.elements {
+		sl.retire(sl.elementMap[element.Member])
+		delete(sl.elementMap, element.Member)
+	}
+	sl.length -= count
+}
+
+// RemoveRankRange removes the elements within a rank range in a single pass, and returns them in rank order
+func (sl *TypedSkipList[K, S, D]) RemoveRankRange(start, end int64) []*TypedElement[K, S, D] {
+	run := sl.seekRankRange(start, end)
+	sl.unlinkRun(run)
+	return run.elements
+}
+
+// RemoveScoreRange removes the elements within a score range (inclusive) in a single pass, and returns them in rank order
+func (sl *TypedSkipList[K, S, D]) RemoveScoreRange(min, max S) []*TypedElement[K, S, D] {
+	return sl.RemoveRankRange(sl.scoreRangePositions(min, max, false, false))
 }
 
-// GetRank gets the rank of a specified member, starting from 1 (rank 1 has the highest score)
-func (sl *SkipList) GetRank(member string, score int64) int64 {
+// PopTop removes the n top ranked elements, and returns them in rank order
+func (sl *TypedSkipList[K, S, D]) PopTop(n int64) []*TypedElement[K, S, D] {
+	return sl.RemoveRankRange(1, n)
+}
+
+// PopBottom removes the n bottom ranked elements, and returns them in rank order
+func (sl *TypedSkipList[K, S, D]) PopBottom(n int64) []*TypedElement[K, S, D] {
+	if n <= 0 {
+		return []*TypedElement[K, S, D]{}
+	}
+	return sl.RemoveRankRange(int64(sl.length)-n+1, int64(sl.length))
+}
+
+// GetRank gets the rank of a specified member, starting from 1 (rank 1 has the highest score, or the lowest score in ascending order)
+func (sl *TypedSkipList[K, S, D]) GetRank(member K, score S) int64 {
+	target := sl.lookup(member)
+	if target == nil || target.element.Score != score {
+		return 0
+	}
+
 	var rank uint64 = 0
 	x := sl.head
 
 	for i := sl.level - 1; i >= 0; i-- {
-		// Note the comparison logic: higher scores come first, if scores are the same, sort by member ID lexicographically
-		for x.level[i].forward != nil &&
-			(x.level[i].forward.element.Score > score ||
-				(x.level[i].forward.element.Score == score &&
-					x.level[i].forward.element.Member < member)) {
-			rank += x.level[i].span
-			x = x.level[i].forward
+		for next := sl.next(x, i); next.forward != nil && sl.compare(&next.forward.element, &target.element) < 0; next = sl.next(x, i) {
+			rank += next.span
+			x = next.forward
 		}
 	}
 
-	x = x.level[0].forward
-	if x != nil && x.element.Member == member {
+	x = sl.next(x, 0).forward
+	if x == target {
 		return int64(rank + 1)
 	}
 
@@ -230,7 +481,16 @@ func (sl *SkipList) GetRank(member string, score int64) int64 {
 }
 
 // GetByRank gets an element by its rank, rank starts from 1
-func (sl *SkipList) GetByRank(rank int64) *Element {
+func (sl *TypedSkipList[K, S, D]) GetByRank(rank int64) *TypedElement[K, S, D] {
+	x := sl.getNodeByRank(rank)
+	if x == nil {
+		return nil
+	}
+	return &x.element
+}
+
+// getNodeByRank gets a node by its rank using the spans, rank starts from 1
+func (sl *TypedSkipList[K, S, D]) getNodeByRank(rank int64) *node[K, S, D] {
 	if rank <= 0 || rank > int64(sl.length) {
 		return nil
 	}
@@ -239,13 +499,13 @@ func (sl *SkipList) GetByRank(rank int64) *Element {
 	x := sl.head
 
 	for i := sl.level - 1; i >= 0; i-- {
-		for x.level[i].forward != nil && traversed+x.level[i].span <= uint64(rank) {
-			traversed += x.level[i].span
-			x = x.level[i].forward
+		for next := sl.next(x, i); next.forward != nil && traversed+next.span <= uint64(rank); next = sl.next(x, i) {
+			traversed += next.span
+			x = next.forward
 		}
 
 		if traversed == uint64(rank) {
-			return &x.element
+			return x
 		}
 	}
 
@@ -253,15 +513,15 @@ func (sl *SkipList) GetByRank(rank int64) *Element {
 }
 
 // GetElementByMember gets an element by member name
-func (sl *SkipList) GetElementByMember(member string) *Element {
-	if node, ok := sl.elementMap[member]; ok {
+func (sl *TypedSkipList[K, S, D]) GetElementByMember(member K) *TypedElement[K, S, D] {
+	if node := sl.lookup(member); node != nil {
 		return &node.element
 	}
 	return nil
 }
 
 // UpdateScore updates a member's score
-func (sl *SkipList) UpdateScore(member string, newScore int64) bool {
+func (sl *TypedSkipList[K, S, D]) UpdateScore(member K, newScore S) bool {
 	if node, ok := sl.elementMap[member]; ok {
 		oldScore := node.element.Score
 		data := node.element.Data
@@ -275,8 +535,8 @@ func (sl *SkipList) UpdateScore(member string, newScore int64) bool {
 }
 
 // GetRankRange gets elements within a specified rank range
-func (sl *SkipList) GetRankRange(start, end int64) []*Element {
-	var elements []*Element
+func (sl *TypedSkipList[K, S, D]) GetRankRange(start, end int64) []*TypedElement[K, S, D] {
+	var elements []*TypedElement[K, S, D]
 
 	// Boundary check
 	if start <= 0 {
@@ -291,20 +551,20 @@ func (sl *SkipList) GetRankRange(start, end int64) []*Element {
 		return elements
 	}
 
-	// Get elements in the specified range
-	for i := start; i <= end; i++ {
-		element := sl.GetByRank(i)
-		if element != nil {
-			elements = append(elements, element)
-		}
+	// Seek to the start rank once, then walk the bottom level
+	elements = make([]*TypedElement[K, S, D], 0, end-start+1)
+	x := sl.getNodeByRank(start)
+	for i := start; i <= end && x != nil; i++ {
+		elements = append(elements, &x.element)
+		x = sl.next(x, 0).forward
 	}
 
 	return elements
 }
 
-// GetScoreRange gets elements within a specified score range
-func (sl *SkipList) GetScoreRange(min, max int64) []*Element {
-	var elements []*Element
+// GetScoreRange gets elements within a specified score range (inclusive), in rank order
+func (sl *TypedSkipList[K, S, D]) GetScoreRange(min, max S) []*TypedElement[K, S, D] {
+	var elements []*TypedElement[K, S, D]
 
 	// Boundary check
 	if min > max {
@@ -312,26 +572,153 @@ func (sl *SkipList) GetScoreRange(min, max int64) []*Element {
 	}
 
 	// Find the first node whose score is in the range
+	first, last := sl.scoreBounds(min, max)
+	x, _ := sl.seekScore(first)
+
+	// Collect all nodes within the range
+	for x != nil && sl.compareScore(x.element.Score, last) <= 0 {
+		elements = append(elements, &x.element)
+		x = sl.next(x, 0).forward
+	}
+
+	return elements
+}
+
+// scoreBounds returns the first and last scores of a score range in rank order
+func (sl *TypedSkipList[K, S, D]) scoreBounds(min, max S) (first, last S) {
+	if sl.ascending {
+		return min, max
+	}
+	return max, min
+}
+
+// seekScore finds the first node whose score is not ranked before the given score, and its rank
+func (sl *TypedSkipList[K, S, D]) seekScore(score S) (*node[K, S, D], int64) {
+	var rank uint64 = 0
 	x := sl.head
+
 	for i := sl.level - 1; i >= 0; i-- {
-		for x.level[i].forward != nil && x.level[i].forward.element.Score > max {
-			x = x.level[i].forward
+		for next := sl.next(x, i); next.forward != nil && sl.compareScore(next.forward.element.Score, score) < 0; next = sl.next(x, i) {
+			rank += next.span
+			x = next.forward
 		}
 	}
 
-	// Move to the first node that has a score <= max
-	x = x.level[0].forward
+	return sl.next(x, 0).forward, int64(rank + 1)
+}
 
-	// Collect all nodes within the range
-	for x != nil && x.element.Score >= min {
-		elements = append(elements, &x.element)
-		x = x.level[0].forward
+// countBefore counts the elements whose score is ranked before the given score,
+// including the elements with an equal score when inclusive is true
+func (sl *TypedSkipList[K, S, D]) countBefore(score S, inclusive bool) uint64 {
+	var count uint64 = 0
+	x := sl.head
+
+	for i := sl.level - 1; i >= 0; i-- {
+		for next := sl.next(x, i); next.forward != nil; next = sl.next(x, i) {
+			c := sl.compareScore(next.forward.element.Score, score)
+			if c > 0 || (c == 0 && !inclusive) {
+				break
+			}
+			count += next.span
+			x = next.forward
+		}
 	}
 
-	return elements
+	return count
+}
+
+// countLess counts the elements ordered before an element, which doesn't need to be in the skip list
+func (sl *TypedSkipList[K, S, D]) countLess(element *TypedElement[K, S, D]) uint64 {
+	var count uint64 = 0
+	x := sl.head
+
+	for i := sl.level - 1; i >= 0; i-- {
+		for next := sl.next(x, i); next.forward != nil && sl.compare(&next.forward.element, element) < 0; next = sl.next(x, i) {
+			count += next.span
+			x = next.forward
+		}
+	}
+
+	return count
+}
+
+// NewSkipListFromSorted builds a skip list from elements already sorted in the rank order of the configuration,
+// in a single pass without any comparison search or random level. ErrDuplicateMember or ErrNotSorted is returned
+// if the members are not distinct or not in strict rank order
+func NewSkipListFromSorted[K cmp.Ordered, S cmp.Ordered, D any](config SkipListConfig[K, S, D], elements []TypedElement[K, S, D]) (*TypedSkipList[K, S, D], error) {
+	sl := NewSkipListWithConfig(config)
+	sl.elementMap = make(map[K]*node[K, S, D], len(elements))
+
+	// last node on every level, and its ordinal position
+	var last [MaxLevel]*node[K, S, D]
+	var rank [MaxLevel]uint64
+	for i := range last {
+		last[i] = sl.head
+	}
+
+	for _, element := range elements {
+		if _, ok := sl.elementMap[element.Member]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateMember, element.Member)
+		}
+		if sl.tail != nil && sl.compare(&sl.tail.element, &element) >= 0 {
+			return nil, fmt.Errorf("%w: %v is ranked before %v", ErrNotSorted, element.Member, sl.tail.element.Member)
+		}
+
+		position := sl.length + 1
+		level := sortedLevel(position)
+		if level > sl.level {
+			sl.level = level
+		}
+
+		newNode := &node[K, S, D]{
+			element:   element,
+			nodeLinks: nodeLinks[K, S, D]{backward: sl.tail, level: make([]*levelNode[K, S, D], level)},
+		}
+		for i := 0; i < level; i++ {
+			newNode.level[i] = &levelNode[K, S, D]{}
+			last[i].level[i].forward = newNode
+			last[i].level[i].span = position - rank[i]
+			last[i] = newNode
+			rank[i] = position
+		}
+
+		sl.tail = newNode
+		sl.elementMap[element.Member] = newNode
+		sl.length++
+	}
+
+	// The last node of every level spans to the end of the list
+	for i := 0; i < sl.level; i++ {
+		last[i].level[i].span = sl.length - rank[i]
+	}
+
+	return sl, nil
+}
+
+// clone copies the skip list in a single pass, the additional data is copied as is
+func (sl *TypedSkipList[K, S, D]) clone() *TypedSkipList[K, S, D] {
+	elements := make([]TypedElement[K, S, D], 0, sl.length)
+	for _, element := range sl.All() {
+		elements = append(elements, *element)
+	}
+
+	// The elements are already in rank order
+	clone, _ := NewSkipListFromSorted(SkipListConfig[K, S, D]{Ascending: sl.ascending, Comparator: sl.comparator}, elements)
+	return clone
+}
+
+// sortedLevel gets the level of the node at an ordinal position of a bulk-built skip list, every 4th node is
+// promoted to level 2, every 16th node to level 3 and so on, matching the promotion probability
+func sortedLevel(position uint64) int {
+	level := 1
+	for level < MaxLevel && position%4 == 0 {
+		position /= 4
+		level++
+	}
+	return level
 }
 
 // Len returns the number of elements in the skip list
-func (sl *SkipList) Len() uint64 {
+func (sl *TypedSkipList[K, S, D]) Len() uint64 {
 	return sl.length
 }
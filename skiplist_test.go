@@ -1,6 +1,8 @@
 package rank
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -172,3 +174,333 @@ func TestSkipListLarge(t *testing.T) {
 		}
 	}
 }
+
+func TestSkipListTyped(t *testing.T) {
+	type payload struct {
+		Name string
+	}
+
+	sl := NewTypedSkipList[int, float64, payload]()
+
+	sl.Insert(1, 1.5, payload{Name: "one"})
+	sl.Insert(2, 2.5, payload{Name: "two"})
+	sl.Insert(3, 0.5, payload{Name: "three"})
+
+	// Data is typed, no type assertion is needed
+	element := sl.GetElementByMember(2)
+	if element == nil {
+		t.Fatal("Failed to get element for member 2")
+	}
+
+	if element.Data.Name != "two" {
+		t.Errorf("Expected data name 'two', got %s", element.Data.Name)
+	}
+
+	rank := sl.GetRank(3, 0.5)
+	if rank != 3 {
+		t.Errorf("Expected rank 3, got %d", rank)
+	}
+
+	element = sl.GetByRank(1)
+	if element == nil || element.Member != 2 {
+		t.Errorf("Expected member 2 at rank 1, got %v", element)
+	}
+}
+
+func TestSkipListComparator(t *testing.T) {
+	sl := NewSkipListWithConfig(SkipListConfig[string, int64, int]{
+		Comparator: MemberDesc[string, int64, int],
+	})
+
+	sl.Insert("a", 100, 0)
+	sl.Insert("b", 100, 0)
+	sl.Insert("c", 100, 0)
+	sl.Insert("d", 200, 0)
+
+	// Higher score still comes first, ties are ranked by member ID descending
+	expected := []string{"d", "c", "b", "a"}
+	for i, member := range expected {
+		element := sl.GetByRank(int64(i + 1))
+		if element == nil || element.Member != member {
+			t.Errorf("Expected %s at rank %d, got %v", member, i+1, element)
+		}
+		if rank := sl.GetRank(member, element.Score); rank != int64(i+1) {
+			t.Errorf("Expected rank %d for %s, got %d", i+1, member, rank)
+		}
+	}
+
+	// Test deletion with a custom comparator
+	if !sl.Delete("b", 100) {
+		t.Error("Failed to delete b")
+	}
+
+	if rank := sl.GetRank("a", 100); rank != 3 {
+		t.Errorf("Expected rank 3 for a after deletion, got %d", rank)
+	}
+
+	// Test tie-break on the additional data
+	byData := NewSkipListWithConfig(SkipListConfig[string, int64, int]{
+		Comparator: func(a, b *TypedElement[string, int64, int]) int {
+			return a.Data - b.Data
+		},
+	})
+
+	byData.Insert("x", 100, 3)
+	byData.Insert("y", 100, 1)
+	byData.Insert("z", 100, 2)
+
+	if rank := byData.GetRank("y", 100); rank != 1 {
+		t.Errorf("Expected rank 1 for y, got %d", rank)
+	}
+
+	if rank := byData.GetRank("x", 100); rank != 3 {
+		t.Errorf("Expected rank 3 for x, got %d", rank)
+	}
+}
+
+func TestSkipListAscending(t *testing.T) {
+	sl := NewSkipListWithConfig(SkipListConfig[string, int64, int]{
+		Ascending: true,
+	})
+
+	// Insert some data
+	for i := 1; i <= 10; i++ {
+		sl.Insert("key"+string(rune('0'+i)), int64(i*100), i)
+	}
+
+	// Test ranking - scores are sorted in ascending order
+	element := sl.GetByRank(1)
+	if element == nil || element.Score != 100 {
+		t.Errorf("Expected score 100 at rank 1, got %v", element)
+	}
+
+	if rank := sl.GetRank("key3", 300); rank != 3 {
+		t.Errorf("Expected rank 3, got %d", rank)
+	}
+
+	// Test getting score range, elements are returned in rank order
+	elements := sl.GetScoreRange(300, 700)
+	if len(elements) != 5 {
+		t.Fatalf("Expected 5 elements in range, got %d", len(elements))
+	}
+
+	if elements[0].Score != 300 || elements[4].Score != 700 {
+		t.Errorf("Expected scores from 300 to 700, got %d to %d", elements[0].Score, elements[4].Score)
+	}
+
+	elements = sl.GetScoreRange(0, 100)
+	if len(elements) != 1 {
+		t.Errorf("Expected 1 element in range, got %d", len(elements))
+	}
+
+	// Test descending score range order
+	desc := NewSkipList()
+	for i := 1; i <= 10; i++ {
+		desc.Insert("key"+string(rune('0'+i)), int64(i*100), i)
+	}
+
+	descElements := desc.GetScoreRange(300, 700)
+	if len(descElements) != 5 || descElements[0].Score != 700 || descElements[4].Score != 300 {
+		t.Errorf("Expected scores from 700 to 300 in descending order, got %v", descElements)
+	}
+}
+
+func TestSkipListRemoveRange(t *testing.T) {
+	newList := func() *SkipList {
+		sl := NewSkipList()
+		for i := 1; i <= 100; i++ {
+			sl.Insert(fmt.Sprintf("key%03d", i), int64(i), nil)
+		}
+		return sl
+	}
+
+	// checkList verifies that spans, backward pointers and the tail are still consistent
+	checkList := func(t *testing.T, sl *SkipList, length uint64) {
+		t.Helper()
+
+		if sl.Len() != length {
+			t.Errorf("Expected length %d, got %d", length, sl.Len())
+		}
+
+		var count int64 = 0
+		for rank, element := range sl.All() {
+			count++
+			if sl.GetRank(element.Member, element.Score) != rank {
+				t.Errorf("Expected %s at rank %d, got %d", element.Member, rank, sl.GetRank(element.Member, element.Score))
+			}
+			if byRank := sl.GetByRank(rank); byRank != element {
+				t.Errorf("Expected %s at rank %d, got %v", element.Member, rank, byRank)
+			}
+		}
+
+		backward := int64(0)
+		for range sl.Backward() {
+			backward++
+		}
+		if count != int64(length) || backward != int64(length) {
+			t.Errorf("Expected %d elements both ways, got %d forward and %d backward", length, count, backward)
+		}
+	}
+
+	// Test removing a rank range
+	sl := newList()
+	elements := sl.RemoveRankRange(11, 20)
+	if len(elements) != 10 || elements[0].Score != 90 || elements[9].Score != 81 {
+		t.Errorf("Expected scores 90 to 81 to be removed, got %v", elements)
+	}
+	if sl.GetElementByMember("key085") != nil {
+		t.Error("Expected key085 to be removed")
+	}
+	checkList(t, sl, 90)
+
+	// Test popping from both ends
+	elements = sl.PopTop(3)
+	if len(elements) != 3 || elements[0].Score != 100 || elements[2].Score != 98 {
+		t.Errorf("Expected scores 100 to 98 to be popped, got %v", elements)
+	}
+	checkList(t, sl, 87)
+
+	elements = sl.PopBottom(2)
+	if len(elements) != 2 || elements[0].Score != 2 || elements[1].Score != 1 {
+		t.Errorf("Expected scores 2 and 1 to be popped, got %v", elements)
+	}
+	checkList(t, sl, 85)
+
+	// Test removing a score range, the bounds are inclusive
+	elements = sl.RemoveScoreRange(50, 60)
+	if len(elements) != 11 || elements[0].Score != 60 || elements[10].Score != 50 {
+		t.Errorf("Expected scores 60 to 50 to be removed, got %v", elements)
+	}
+	checkList(t, sl, 74)
+
+	// Test out of range removals
+	if elements = sl.RemoveRankRange(80, 90); len(elements) != 0 {
+		t.Errorf("Expected nothing to be removed, got %d elements", len(elements))
+	}
+	if elements = sl.RemoveScoreRange(60, 50); len(elements) != 0 {
+		t.Errorf("Expected nothing to be removed, got %d elements", len(elements))
+	}
+	if elements = sl.PopBottom(0); len(elements) != 0 {
+		t.Errorf("Expected nothing to be popped, got %d elements", len(elements))
+	}
+
+	// Test removing everything
+	elements = sl.PopBottom(1000)
+	if len(elements) != 74 {
+		t.Errorf("Expected 74 elements to be popped, got %d", len(elements))
+	}
+	checkList(t, sl, 0)
+
+	sl.Insert("key", 1, nil)
+	checkList(t, sl, 1)
+
+	// Test ascending score range removal
+	asc := NewSkipListWithConfig(SkipListConfig[string, int64, interface{}]{Ascending: true})
+	for i := 1; i <= 10; i++ {
+		asc.Insert(fmt.Sprintf("key%d", i), int64(i), nil)
+	}
+	elements = asc.RemoveScoreRange(3, 5)
+	if len(elements) != 3 || elements[0].Score != 3 || elements[2].Score != 5 {
+		t.Errorf("Expected scores 3 to 5 to be removed, got %v", elements)
+	}
+	checkList(t, asc, 7)
+
+	// Test removing runs spanning nodes of every level, then inserting again over the fixed spans
+	sl = NewSkipList()
+	for i := 1; i <= 2000; i++ {
+		sl.Insert(fmt.Sprintf("key%04d", i), int64(i), nil)
+	}
+	for _, r := range [][2]int64{{500, 1499}, {1, 37}, {900, 2000}, {2, 2}, {300, 600}} {
+		length := sl.Len()
+		first, last := sl.GetByRank(r[0]), sl.GetByRank(min(r[1], int64(length)))
+		elements = sl.RemoveRankRange(r[0], r[1])
+		if elements[0] != first || elements[len(elements)-1] != last {
+			t.Errorf("Expected %s to %s to be removed, got %v to %v", first.Member, last.Member, elements[0], elements[len(elements)-1])
+		}
+		checkList(t, sl, length-uint64(len(elements)))
+
+		for i := 0; i < 50; i++ {
+			sl.Insert(fmt.Sprintf("new%d-%03d", r[0], i), r[0]*3+int64(i), nil)
+		}
+		checkList(t, sl, length-uint64(len(elements))+50)
+	}
+}
+
+func TestSkipListFromSorted(t *testing.T) {
+	// Elements sorted by descending score, with ties in ascending member order
+	elements := make([]TypedElement[string, int64, int], 0, 1000)
+	for i := 0; i < 1000; i++ {
+		elements = append(elements, TypedElement[string, int64, int]{Member: fmt.Sprintf("key%04d", i), Score: int64(1000 - i/2), Data: i})
+	}
+
+	sl, err := NewSkipListFromSorted(SkipListConfig[string, int64, int]{}, elements)
+	if err != nil {
+		t.Fatalf("Failed to build skip list: %v", err)
+	}
+
+	if sl.Len() != 1000 {
+		t.Errorf("Expected length 1000, got %d", sl.Len())
+	}
+
+	// Levels are deterministic, 1000 elements reach level 5 with the 256th element
+	if sl.level != 5 {
+		t.Errorf("Expected level 5, got %d", sl.level)
+	}
+
+	for rank, element := range sl.All() {
+		if element.Data != int(rank-1) {
+			t.Errorf("Expected element %d at rank %d, got %d", rank-1, rank, element.Data)
+		}
+		if sl.GetRank(element.Member, element.Score) != rank {
+			t.Errorf("Expected %s at rank %d, got %d", element.Member, rank, sl.GetRank(element.Member, element.Score))
+		}
+		if byRank := sl.GetByRank(rank); byRank != element {
+			t.Errorf("Expected %s at rank %d, got %v", element.Member, rank, byRank)
+		}
+	}
+
+	var backward int64 = 1000
+	for rank := range sl.Backward() {
+		if rank != backward {
+			t.Errorf("Expected rank %d backward, got %d", backward, rank)
+		}
+		backward--
+	}
+
+	// The skip list keeps working after the bulk build
+	sl.Insert("key9999", 2000, -1)
+	sl.Delete("key0500", 750)
+	if rank := sl.GetRank("key0999", 501); rank != 1000 {
+		t.Errorf("Expected rank 1000, got %d", rank)
+	}
+	if element := sl.GetByRank(1); element == nil || element.Member != "key9999" {
+		t.Errorf("Expected key9999 at rank 1, got %v", element)
+	}
+
+	// Test an empty input
+	empty, err := NewSkipListFromSorted(SkipListConfig[string, int64, int]{}, nil)
+	if err != nil || empty.Len() != 0 {
+		t.Errorf("Expected an empty skip list, got %v", err)
+	}
+
+	// Test invalid inputs
+	duplicate := []TypedElement[string, int64, int]{{Member: "a", Score: 3}, {Member: "b", Score: 2}, {Member: "a", Score: 1}}
+	if _, err := NewSkipListFromSorted(SkipListConfig[string, int64, int]{}, duplicate); !errors.Is(err, ErrDuplicateMember) {
+		t.Errorf("Expected ErrDuplicateMember, got %v", err)
+	}
+
+	unsorted := []TypedElement[string, int64, int]{{Member: "a", Score: 1}, {Member: "b", Score: 2}}
+	if _, err := NewSkipListFromSorted(SkipListConfig[string, int64, int]{}, unsorted); !errors.Is(err, ErrNotSorted) {
+		t.Errorf("Expected ErrNotSorted, got %v", err)
+	}
+
+	// The order follows the configuration
+	ascending := SkipListConfig[string, int64, int]{Ascending: true, Comparator: MemberDesc[string, int64, int]}
+	sorted := []TypedElement[string, int64, int]{{Member: "b", Score: 1}, {Member: "a", Score: 1}, {Member: "c", Score: 2}}
+	if _, err := NewSkipListFromSorted(ascending, sorted); err != nil {
+		t.Errorf("Expected the elements to be sorted, got %v", err)
+	}
+	if _, err := NewSkipListFromSorted(SkipListConfig[string, int64, int]{}, sorted); !errors.Is(err, ErrNotSorted) {
+		t.Errorf("Expected ErrNotSorted, got %v", err)
+	}
+}
@@ -0,0 +1,428 @@
+package rank
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"time"
+)
+
+// snapshotMagic identifies a leaderboard snapshot
+const snapshotMagic = "RANK"
+
+// snapshotVersion version of the snapshot format
+const snapshotVersion = 1
+
+// Codec encoding of the additional member data in snapshots
+type Codec interface {
+	// Marshal encodes a value
+	Marshal(v any) ([]byte, error)
+	// Unmarshal decodes data into the value pointed to by v
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec encodes the additional member data as JSON, untyped data is decoded as the generic JSON types
+type JSONCodec struct{}
+
+// Marshal encodes a value as JSON
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes JSON data into the value pointed to by v
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// SaveSnapshot writes the configuration and all the members of the leaderboard to w, in a versioned binary format
+// ending with a CRC-32 checksum. The additional data is encoded with the Codec of the configuration
+func (lb *TypedLeaderboard[D]) SaveSnapshot(w io.Writer) error {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	return lb.saveSnapshot(w)
+}
+
+// saveSnapshot writes a snapshot of the leaderboard to w. The caller must hold the lock
+func (lb *TypedLeaderboard[D]) saveSnapshot(w io.Writer) error {
+	sw := newSnapshotWriter(w)
+	sw.writeRaw([]byte(snapshotMagic))
+	sw.writeUvarint(snapshotVersion)
+	sw.writeConfig(lb.config)
+
+	codec := lb.codec()
+	sw.writeUvarint(lb.skipList.Len())
+	for _, element := range lb.skipList.All() {
+		memberData := element.Data
+
+		data, err := codec.Marshal(memberData.Data)
+		if err != nil {
+			return fmt.Errorf("encode data of member %s: %w", memberData.Member, err)
+		}
+
+		sw.writeString(memberData.Member)
+		sw.writeVarint(memberData.Score)
+		sw.writeTime(memberData.UpdatedAt)
+		sw.writeTime(memberData.ExpiresAt)
+		sw.writeBytes(data)
+	}
+
+	return sw.close()
+}
+
+// LoadSnapshot replaces the configuration and all the members of the leaderboard with a snapshot read from r.
+// The Codec of the current configuration is kept and used to decode the additional data. The leaderboard is left
+// unchanged if the snapshot is invalid, in which case an error wrapping ErrInvalidSnapshot is returned.
+// With a write-ahead log, the loaded members are compacted into a new snapshot
+func (lb *TypedLeaderboard[D]) LoadSnapshot(r io.Reader) error {
+	lb.mutex.RLock()
+	codec := lb.codec()
+	lb.mutex.RUnlock()
+
+	config, skipList, err := readSnapshot[D](r, codec)
+	if err != nil {
+		return err
+	}
+
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	if lb.frozen {
+		return ErrFrozen
+	}
+
+	lb.load(config, skipList)
+
+	// The loaded members replace everything logged so far
+	if lb.wal != nil {
+		return lb.compact()
+	}
+
+	return nil
+}
+
+// readSnapshot decodes a snapshot into a configuration and the skip list of its members, the additional data
+// are decoded with codec
+func readSnapshot[D any](r io.Reader, codec Codec) (LeaderboardConfig, *TypedSkipList[string, int64, TypedMemberData[D]], error) {
+	sr := newSnapshotReader(r)
+
+	magic := sr.readRaw(len(snapshotMagic))
+	if sr.err == nil && string(magic) != snapshotMagic {
+		return LeaderboardConfig{}, nil, fmt.Errorf("%w: bad magic %q", ErrInvalidSnapshot, magic)
+	}
+	if version := sr.readUvarint(); sr.err == nil && version != snapshotVersion {
+		return LeaderboardConfig{}, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+
+	config := sr.readConfig()
+	config.Codec = codec
+
+	// Build the new leaderboard state aside, the members are stored in rank order
+	count := sr.readUvarint()
+	elements := make([]TypedElement[string, int64, TypedMemberData[D]], 0, min(count, 1<<16))
+	for i := uint64(0); i < count && sr.err == nil; i++ {
+		memberData := TypedMemberData[D]{
+			Member:    sr.readString(),
+			Score:     sr.readVarint(),
+			UpdatedAt: sr.readTime(),
+			ExpiresAt: sr.readTime(),
+		}
+
+		data := sr.readBytes()
+		if sr.err != nil {
+			break
+		}
+		if err := codec.Unmarshal(data, &memberData.Data); err != nil {
+			return LeaderboardConfig{}, nil, fmt.Errorf("%w: decode data of member %s: %v", ErrInvalidSnapshot, memberData.Member, err)
+		}
+
+		elements = append(elements, TypedElement[string, int64, TypedMemberData[D]]{
+			Member: memberData.Member,
+			Score:  memberData.Score,
+			Data:   memberData,
+		})
+	}
+
+	if err := sr.close(); err != nil {
+		return LeaderboardConfig{}, nil, err
+	}
+
+	skipList, err := NewSkipListFromSorted(skipListConfig[D](config), elements)
+	if err != nil {
+		return LeaderboardConfig{}, nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	return config, skipList, nil
+}
+
+// load replaces the configuration and all the members of the leaderboard. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) load(config LeaderboardConfig, skipList *TypedSkipList[string, int64, TypedMemberData[D]]) {
+	lb.config = config
+	lb.skipList = skipList
+	lb.scores = newScoreCounter(config)
+	lb.expiry = newExpiryQueue()
+	for _, element := range skipList.All() {
+		lb.trackScore(element.Score)
+		lb.expiry.set(element.Member, element.Data.ExpiresAt)
+	}
+}
+
+// codec gets the codec of the additional data, JSON by default
+func (lb *TypedLeaderboard[D]) codec() Codec {
+	if lb.config.Codec == nil {
+		return JSONCodec{}
+	}
+	return lb.config.Codec
+}
+
+// snapshotWriter writes the fields of a snapshot, keeping the first error and the checksum of all the bytes written
+type snapshotWriter struct {
+	w    *bufio.Writer
+	hash hash.Hash32
+	buf  [binary.MaxVarintLen64]byte
+	err  error
+}
+
+// newSnapshotWriter creates a snapshot writer
+func newSnapshotWriter(w io.Writer) *snapshotWriter {
+	return &snapshotWriter{
+		w:    bufio.NewWriter(w),
+		hash: crc32.NewIEEE(),
+	}
+}
+
+// writeRaw writes bytes as is
+func (sw *snapshotWriter) writeRaw(b []byte) {
+	if sw.err != nil {
+		return
+	}
+	sw.hash.Write(b)
+	_, sw.err = sw.w.Write(b)
+}
+
+// writeUvarint writes an unsigned integer
+func (sw *snapshotWriter) writeUvarint(v uint64) {
+	n := binary.PutUvarint(sw.buf[:], v)
+	sw.writeRaw(sw.buf[:n])
+}
+
+// writeVarint writes a signed integer
+func (sw *snapshotWriter) writeVarint(v int64) {
+	n := binary.PutVarint(sw.buf[:], v)
+	sw.writeRaw(sw.buf[:n])
+}
+
+// writeBool writes a boolean
+func (sw *snapshotWriter) writeBool(v bool) {
+	if v {
+		sw.writeUvarint(1)
+	} else {
+		sw.writeUvarint(0)
+	}
+}
+
+// writeBytes writes length-prefixed bytes
+func (sw *snapshotWriter) writeBytes(b []byte) {
+	sw.writeUvarint(uint64(len(b)))
+	sw.writeRaw(b)
+}
+
+// writeString writes a length-prefixed string
+func (sw *snapshotWriter) writeString(s string) {
+	sw.writeBytes([]byte(s))
+}
+
+// writeTime writes a time with nanosecond precision, the zero time is kept as is
+func (sw *snapshotWriter) writeTime(t time.Time) {
+	sw.writeBool(!t.IsZero())
+	if !t.IsZero() {
+		sw.writeVarint(t.UnixNano())
+	}
+}
+
+// writeConfig writes a leaderboard configuration, except the codec
+func (sw *snapshotWriter) writeConfig(config LeaderboardConfig) {
+	sw.writeString(config.ID)
+	sw.writeString(config.Name)
+	sw.writeBool(config.ScoreOrder)
+	sw.writeVarint(int64(config.UpdatePolicy))
+	sw.writeVarint(int64(config.TieBreak))
+	sw.writeVarint(int64(config.MaxOvertaken))
+	sw.writeVarint(int64(config.RankingMode))
+	sw.writeVarint(int64(config.MaxMembers))
+	sw.writeVarint(int64(config.MemberTTL))
+}
+
+// close writes the checksum and flushes the snapshot
+func (sw *snapshotWriter) close() error {
+	if sw.err != nil {
+		return sw.err
+	}
+
+	var checksum [4]byte
+	binary.BigEndian.PutUint32(checksum[:], sw.hash.Sum32())
+	if _, err := sw.w.Write(checksum[:]); err != nil {
+		return err
+	}
+
+	return sw.w.Flush()
+}
+
+// snapshotReader reads the fields of a snapshot, keeping the first error and the checksum of all the bytes read
+type snapshotReader struct {
+	r    *bufio.Reader
+	hash hash.Hash32
+	buf  [1]byte
+	err  error
+}
+
+// newSnapshotReader creates a snapshot reader
+func newSnapshotReader(r io.Reader) *snapshotReader {
+	return &snapshotReader{
+		r:    bufio.NewReader(r),
+		hash: crc32.NewIEEE(),
+	}
+}
+
+// ReadByte implements io.ByteReader
+func (sr *snapshotReader) ReadByte() (byte, error) {
+	b, err := sr.r.ReadByte()
+	if err == nil {
+		sr.buf[0] = b
+		sr.hash.Write(sr.buf[:])
+	}
+	return b, err
+}
+
+// fail keeps the first error, an unexpected end of the snapshot means that it is truncated
+func (sr *snapshotReader) fail(err error) {
+	if sr.err != nil {
+		return
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = fmt.Errorf("%w: truncated", ErrInvalidSnapshot)
+	}
+	sr.err = err
+}
+
+// readRaw reads n bytes as is
+func (sr *snapshotReader) readRaw(n int) []byte {
+	if sr.err != nil {
+		return nil
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(sr.r, b); err != nil {
+		sr.fail(err)
+		return nil
+	}
+	sr.hash.Write(b)
+	return b
+}
+
+// readUvarint reads an unsigned integer
+func (sr *snapshotReader) readUvarint() uint64 {
+	if sr.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(sr)
+	if err != nil {
+		sr.fail(err)
+	}
+	return v
+}
+
+// readVarint reads a signed integer
+func (sr *snapshotReader) readVarint() int64 {
+	if sr.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadVarint(sr)
+	if err != nil {
+		sr.fail(err)
+	}
+	return v
+}
+
+// readBool reads a boolean
+func (sr *snapshotReader) readBool() bool {
+	return sr.readUvarint() != 0
+}
+
+// readBytes reads length-prefixed bytes
+func (sr *snapshotReader) readBytes() []byte {
+	n := sr.readUvarint()
+	if sr.err != nil {
+		return nil
+	}
+
+	// Don't trust the length for the allocation, a corrupted length would fail on the read anyway
+	if n > uint64(sr.r.Size()) {
+		b, err := io.ReadAll(io.LimitReader(sr.r, int64(n)))
+		if err == nil && uint64(len(b)) != n {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			sr.fail(err)
+			return nil
+		}
+		sr.hash.Write(b)
+		return b
+	}
+	return sr.readRaw(int(n))
+}
+
+// readString reads a length-prefixed string
+func (sr *snapshotReader) readString() string {
+	return string(sr.readBytes())
+}
+
+// readTime reads a time written by writeTime
+func (sr *snapshotReader) readTime() time.Time {
+	if !sr.readBool() {
+		return time.Time{}
+	}
+	return time.Unix(0, sr.readVarint())
+}
+
+// readConfig reads a leaderboard configuration written by writeConfig
+func (sr *snapshotReader) readConfig() LeaderboardConfig {
+	return LeaderboardConfig{
+		ID:           sr.readString(),
+		Name:         sr.readString(),
+		ScoreOrder:   sr.readBool(),
+		UpdatePolicy: UpdatePolicy(sr.readVarint()),
+		TieBreak:     TieBreak(sr.readVarint()),
+		MaxOvertaken: int(sr.readVarint()),
+		RankingMode:  RankingMode(sr.readVarint()),
+		MaxMembers:   int(sr.readVarint()),
+		MemberTTL:    time.Duration(sr.readVarint()),
+	}
+}
+
+// close checks the checksum at the end of the snapshot
+func (sr *snapshotReader) close() error {
+	if sr.err != nil {
+		return sr.err
+	}
+
+	expected := sr.hash.Sum32()
+
+	var checksum [4]byte
+	if _, err := io.ReadFull(sr.r, checksum[:]); err != nil {
+		sr.fail(err)
+		return sr.err
+	}
+
+	if binary.BigEndian.Uint32(checksum[:]) != expected {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package rank
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// gobCodec encodes the additional data with encoding/gob
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(v)
+	return buf.Bytes(), err
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func TestLeaderboardSnapshot(t *testing.T) {
+	type PlayerInfo struct {
+		Nickname string
+		Level    int
+	}
+
+	for _, codec := range []Codec{nil, gobCodec{}} {
+		t.Run(fmt.Sprintf("%T", codec), func(t *testing.T) {
+			config := LeaderboardConfig{
+				ID:           "snapshot",
+				Name:         "Snapshot Test",
+				ScoreOrder:   false,
+				UpdatePolicy: UpdateIfHigher,
+				TieBreak:     TieBreakEarliest,
+				RankingMode:  RankingDense,
+				MemberTTL:    time.Hour,
+				Codec:        codec,
+			}
+
+			lb := NewTypedLeaderboard[PlayerInfo](config)
+			now := time.Unix(1700000000, 0)
+			lb.now = func() time.Time {
+				now = now.Add(time.Second)
+				return now
+			}
+
+			for i := 0; i < 1000; i++ {
+				lb.Add(fmt.Sprintf("player%d", i), int64(i%100), PlayerInfo{Nickname: fmt.Sprintf("Player %d", i), Level: i})
+			}
+
+			var buf bytes.Buffer
+			if err := lb.SaveSnapshot(&buf); err != nil {
+				t.Fatalf("Failed to save snapshot: %v", err)
+			}
+
+			loaded := NewTypedLeaderboard[PlayerInfo](LeaderboardConfig{Codec: codec})
+			loaded.now = func() time.Time {
+				return now
+			}
+			if err := loaded.LoadSnapshot(&buf); err != nil {
+				t.Fatalf("Failed to load snapshot: %v", err)
+			}
+
+			if loaded.config.ID != "snapshot" || loaded.config.UpdatePolicy != UpdateIfHigher || loaded.config.MemberTTL != time.Hour {
+				t.Errorf("Expected the configuration to be restored, got %+v", loaded.config)
+			}
+
+			if loaded.GetTotal() != lb.GetTotal() {
+				t.Fatalf("Expected %d members, got %d", lb.GetTotal(), loaded.GetTotal())
+			}
+
+			// Every member keeps its rank, data, update time and expiry time
+			expected, _ := lb.GetRankList(1, 1000)
+			actual, _ := loaded.GetRankList(1, 1000)
+			for i := range expected {
+				if actual[i].Member != expected[i].Member || actual[i].Rank != expected[i].Rank || actual[i].Data != expected[i].Data ||
+					!actual[i].UpdatedAt.Equal(expected[i].UpdatedAt) || !actual[i].ExpiresAt.Equal(expected[i].ExpiresAt) {
+					t.Fatalf("Expected %+v at position %d, got %+v", expected[i], i+1, actual[i])
+				}
+			}
+
+			// The loaded leaderboard keeps working, with its dense scores and expiry times
+			now = now.Add(30 * time.Minute)
+			loaded.Add("new", 50, PlayerInfo{})
+			if rank, _ := loaded.GetRank("new"); rank != 51 {
+				t.Errorf("Expected rank 51, got %d", rank)
+			}
+
+			now = now.Add(45 * time.Minute)
+			if total := loaded.GetTotal(); total != 1 {
+				t.Errorf("Expected only the new member after expiry, got %d members", total)
+			}
+		})
+	}
+}
+
+func TestLeaderboardSnapshotInvalid(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "snapshot_invalid",
+		Name:         "Snapshot Invalid Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+	lb.Add("player1", 100, "data")
+	lb.Add("player2", 200, nil)
+
+	var buf bytes.Buffer
+	if err := lb.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+	snapshot := buf.Bytes()
+
+	corrupted := bytes.Clone(snapshot)
+	corrupted[len(corrupted)-10] ^= 0xff
+
+	badVersion := bytes.Clone(snapshot)
+	badVersion[4] = 2
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Empty", nil},
+		{"BadMagic", []byte("JUNKJUNK")},
+		{"BadVersion", badVersion},
+		{"Truncated", snapshot[:len(snapshot)-6]},
+		{"Corrupted", corrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := NewLeaderboard(LeaderboardConfig{ID: "target", ScoreOrder: true})
+			target.Add("existing", 1, nil)
+
+			err := target.LoadSnapshot(bytes.NewReader(tt.data))
+			if !errors.Is(err, ErrInvalidSnapshot) {
+				t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+			}
+
+			// The leaderboard is left unchanged
+			if target.GetTotal() != 1 || target.config.ID != "target" {
+				t.Errorf("Expected the leaderboard to be unchanged, got %d members in %s", target.GetTotal(), target.config.ID)
+			}
+		})
+	}
+
+	// The untouched snapshot still loads
+	target := NewLeaderboard(LeaderboardConfig{})
+	if err := target.LoadSnapshot(bytes.NewReader(snapshot)); err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+	if data, _ := GetDataAs[string](target, "player1"); data != "data" {
+		t.Errorf("Expected data to be restored, got %q", data)
+	}
+}
+
+func TestLeaderboardSnapshotWallClock(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, TieBreak: TieBreakEarliest})
+	for i := 0; i < 10; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), 100, nil)
+	}
+
+	// Update times are kept without the monotonic clock reading, so that ties are broken on the wall time
+	// saved in snapshots
+	memberData, _ := lb.GetMember("player0")
+	if memberData.UpdatedAt != memberData.UpdatedAt.Round(0) {
+		t.Errorf("Expected an update time without monotonic clock reading, got %v", memberData.UpdatedAt)
+	}
+
+	var buf bytes.Buffer
+	if err := lb.SaveSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to save snapshot: %v", err)
+	}
+	restored := NewLeaderboard(LeaderboardConfig{})
+	if err := restored.LoadSnapshot(&buf); err != nil {
+		t.Fatalf("Failed to load snapshot: %v", err)
+	}
+
+	expected, _ := lb.GetRankList(1, 10)
+	actual, _ := restored.GetRankList(1, 10)
+	for i := range expected {
+		if actual[i].Member != expected[i].Member {
+			t.Errorf("Expected %s at rank %d, got %s", expected[i].Member, i+1, actual[i].Member)
+		}
+	}
+}
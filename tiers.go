@@ -0,0 +1,101 @@
+package rank
+
+import (
+	"fmt"
+	"math"
+)
+
+// TierSpec reward tier, either a range of ranks or a top percentage of the members
+type TierSpec struct {
+	// Name name of the tier
+	Name string
+	// MinRank first rank of the tier, in the ranking mode
+	MinRank int64
+	// MaxRank last rank of the tier, inclusive, 0 for MinRank only
+	MaxRank int64
+	// TopPercent percentage in (0, 100] of the top members, used instead of the rank range when set.
+	// The members tied with the last member within the percentage are in the tier too
+	TopPercent float64
+}
+
+// Tier members of a reward tier
+type Tier[D any] struct {
+	// TierSpec specification of the tier
+	TierSpec
+	// Members members of the tier in rank order
+	Members []*TypedRankData[D]
+}
+
+// ComputeTiers gets the members of every reward tier, in the order of the specifications. A member only belongs
+// to the first tier it qualifies for, so that tiers like "rank 1", "ranks 2-10" and "top 10%" don't overlap.
+// An error wrapping ErrInvalidTier is returned if a specification is invalid
+func (lb *TypedLeaderboard[D]) ComputeTiers(specs []TierSpec) ([]Tier[D], error) {
+	lb.lockRead()
+	defer lb.mutex.RUnlock()
+
+	// Rank range of every tier
+	tiers := make([]Tier[D], len(specs))
+	ranks := make([][2]int64, len(specs))
+	var last int64
+	for i, spec := range specs {
+		first, end, err := lb.tierRanks(spec)
+		if err != nil {
+			return nil, err
+		}
+
+		tiers[i] = Tier[D]{TierSpec: spec, Members: []*TypedRankData[D]{}}
+		ranks[i] = [2]int64{first, end}
+		last = max(last, end)
+	}
+
+	// Walk down the ranks until the last tier ends
+	r := ranker[D]{lb: lb}
+	for position, element := range lb.skipList.All() {
+		rank := r.next(position, element)
+		if rank > last {
+			break
+		}
+
+		for i := range ranks {
+			if rank >= ranks[i][0] && rank <= ranks[i][1] {
+				tiers[i].Members = append(tiers[i].Members, &TypedRankData[D]{
+					Rank:            rank,
+					TypedMemberData: element.Data,
+				})
+				break
+			}
+		}
+	}
+
+	return tiers, nil
+}
+
+// tierRanks gets the range of ranks of a tier in the ranking mode, the range is empty if no member qualifies
+func (lb *TypedLeaderboard[D]) tierRanks(spec TierSpec) (first, last int64, err error) {
+	if spec.TopPercent == 0 {
+		last = spec.MaxRank
+		if last == 0 {
+			last = spec.MinRank
+		}
+		if spec.MinRank < 1 || last < spec.MinRank {
+			return 0, 0, fmt.Errorf("%w %q: rank range %d-%d", ErrInvalidTier, spec.Name, spec.MinRank, spec.MaxRank)
+		}
+		return spec.MinRank, last, nil
+	}
+
+	if spec.MinRank != 0 || spec.MaxRank != 0 {
+		return 0, 0, fmt.Errorf("%w %q: both a rank range and a percentage", ErrInvalidTier, spec.Name)
+	}
+	if math.IsNaN(spec.TopPercent) || spec.TopPercent <= 0 || spec.TopPercent > 100 {
+		return 0, 0, fmt.Errorf("%w %q: %w", ErrInvalidTier, spec.Name, ErrInvalidPercentile)
+	}
+
+	// The tier runs down to the rank of the last member within the percentage
+	position := percentilePosition(spec.TopPercent, lb.skipList.Len())
+	element := lb.skipList.GetByRank(position)
+	if element == nil {
+		return 1, 0, nil
+	}
+
+	return 1, lb.rankAt(element, position), nil
+}
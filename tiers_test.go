@@ -0,0 +1,128 @@
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestLeaderboardComputeTiers(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "tiers",
+		Name:         "Tiers Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	})
+
+	for i := 1; i <= 1000; i++ {
+		lb.Add(fmt.Sprintf("player%04d", i), int64(i), nil)
+	}
+	lb.Freeze()
+
+	tiers, err := lb.ComputeTiers([]TierSpec{
+		{Name: "champion", MinRank: 1},
+		{Name: "top 10", MinRank: 2, MaxRank: 10},
+		{Name: "top 1%", TopPercent: 1},
+		{Name: "top 10%", TopPercent: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compute tiers: %v", err)
+	}
+
+	// Every member belongs to the first tier it qualifies for
+	expected := []struct {
+		name        string
+		count       int
+		first, last int64
+	}{
+		{"champion", 1, 1, 1},
+		{"top 10", 9, 2, 10},
+		{"top 1%", 0, 0, 0},
+		{"top 10%", 90, 11, 100},
+	}
+	for i, e := range expected {
+		tier := tiers[i]
+		if tier.Name != e.name || len(tier.Members) != e.count {
+			t.Errorf("Expected %d members in tier %s, got %d in %s", e.count, e.name, len(tier.Members), tier.Name)
+			continue
+		}
+		if e.count > 0 && (tier.Members[0].Rank != e.first || tier.Members[e.count-1].Rank != e.last) {
+			t.Errorf("Expected ranks %d to %d in tier %s, got %d to %d", e.first, e.last, e.name, tier.Members[0].Rank, tier.Members[e.count-1].Rank)
+		}
+	}
+	if tiers[0].Members[0].Member != "player1000" {
+		t.Errorf("Expected player1000 as champion, got %s", tiers[0].Members[0].Member)
+	}
+
+	// Tiers are also computed on a point-in-time view
+	view := lb.Snapshot()
+	viewTiers, err := view.ComputeTiers([]TierSpec{{Name: "top 10", MinRank: 1, MaxRank: 10}})
+	if err != nil || len(viewTiers[0].Members) != 10 || viewTiers[0].Members[0].Member != "player1000" {
+		t.Errorf("Expected the top 10 of the view, got %v %v", viewTiers, err)
+	}
+
+	// Test invalid specifications
+	for _, spec := range []TierSpec{
+		{Name: "zero"},
+		{Name: "reversed", MinRank: 10, MaxRank: 5},
+		{Name: "both", MinRank: 1, TopPercent: 10},
+		{Name: "percent", TopPercent: 101},
+		{Name: "nan", TopPercent: math.NaN()},
+	} {
+		if _, err := lb.ComputeTiers([]TierSpec{spec}); !errors.Is(err, ErrInvalidTier) {
+			t.Errorf("Expected ErrInvalidTier for %s, got %v", spec.Name, err)
+		}
+	}
+
+	// Test an empty leaderboard
+	empty := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	tiers, err = empty.ComputeTiers([]TierSpec{{Name: "champion", MinRank: 1}, {Name: "top 10%", TopPercent: 10}})
+	if err != nil || len(tiers) != 2 || len(tiers[0].Members) != 0 || len(tiers[1].Members) != 0 {
+		t.Errorf("Expected empty tiers, got %v %v", tiers, err)
+	}
+}
+
+func TestLeaderboardComputeTiersTies(t *testing.T) {
+	// Scores 100, 100, 90, 80, 80, 80, 70, 60, 50, 40
+	scores := []int64{100, 100, 90, 80, 80, 80, 70, 60, 50, 40}
+
+	tests := []struct {
+		mode     RankingMode
+		expected [][]string
+	}{
+		// The last member within the top 20% is the second one, tied at rank 1
+		{RankingOrdinal, [][]string{{"m00"}, {"m01"}, {"m02", "m03"}}},
+		{RankingStandard, [][]string{{"m00", "m01"}, {}, {"m02", "m03", "m04", "m05"}}},
+		{RankingDense, [][]string{{"m00", "m01"}, {}, {"m03", "m04", "m05", "m06"}}},
+		{RankingModified, [][]string{{}, {"m00", "m01"}, {"m02"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("mode%d", tt.mode), func(t *testing.T) {
+			lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, RankingMode: tt.mode})
+			for i, score := range scores {
+				lb.Add(fmt.Sprintf("m%02d", i), score, nil)
+			}
+
+			tiers, err := lb.ComputeTiers([]TierSpec{
+				{Name: "first", MinRank: 1},
+				{Name: "top 20%", TopPercent: 20},
+				{Name: "ranks 3-4", MinRank: 3, MaxRank: 4},
+			})
+			if err != nil {
+				t.Fatalf("Failed to compute tiers: %v", err)
+			}
+
+			for i, members := range tt.expected {
+				actual := make([]string, 0, len(tiers[i].Members))
+				for _, rankData := range tiers[i].Members {
+					actual = append(actual, rankData.Member)
+				}
+				if fmt.Sprint(actual) != fmt.Sprint(members) {
+					t.Errorf("Expected %v in tier %s, got %v", members, tiers[i].Name, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package rank
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// expiryItem a member with an expiry time
+type expiryItem struct {
+	member    string
+	expiresAt time.Time
+	index     int
+}
+
+// expiryQueue members with an expiry time, ordered by expiry time, the first member expires first
+type expiryQueue struct {
+	items   []*expiryItem
+	members map[string]*expiryItem
+}
+
+// newExpiryQueue creates an empty expiry queue
+func newExpiryQueue() *expiryQueue {
+	return &expiryQueue{
+		members: make(map[string]*expiryItem),
+	}
+}
+
+// Len implements heap.Interface
+func (q *expiryQueue) Len() int {
+	return len(q.items)
+}
+
+// Less implements heap.Interface
+func (q *expiryQueue) Less(i, j int) bool {
+	return q.items[i].expiresAt.Before(q.items[j].expiresAt)
+}
+
+// Swap implements heap.Interface
+func (q *expiryQueue) Swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.items[i].index = i
+	q.items[j].index = j
+}
+
+// Push implements heap.Interface
+func (q *expiryQueue) Push(x any) {
+	item := x.(*expiryItem)
+	item.index = len(q.items)
+	q.items = append(q.items, item)
+	q.members[item.member] = item
+}
+
+// Pop implements heap.Interface
+func (q *expiryQueue) Pop() any {
+	n := len(q.items)
+	item := q.items[n-1]
+	q.items[n-1] = nil
+	q.items = q.items[:n-1]
+	delete(q.members, item.member)
+	return item
+}
+
+// set sets the expiry time of a member, a zero time means the member never expires
+func (q *expiryQueue) set(member string, expiresAt time.Time) {
+	item, ok := q.members[member]
+	switch {
+	case expiresAt.IsZero():
+		if ok {
+			heap.Remove(q, item.index)
+		}
+	case ok:
+		item.expiresAt = expiresAt
+		heap.Fix(q, item.index)
+	default:
+		heap.Push(q, &expiryItem{member: member, expiresAt: expiresAt})
+	}
+}
+
+// remove stops tracking the expiry time of a member
+func (q *expiryQueue) remove(member string) {
+	if item, ok := q.members[member]; ok {
+		heap.Remove(q, item.index)
+	}
+}
+
+// AddWithTTL adds or updates a member's score like Add, the member expires ttl after this write unless it is
+// updated again. A ttl of 0 or less uses the MemberTTL of the configuration
+func (lb *TypedLeaderboard[D]) AddWithTTL(member string, score int64, data D, ttl time.Duration) (*AddResult[D], error) {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	result := lb.add(member, score, data, ttl, true)
+	return &result, result.Err
+}
+
+// StartSweeper starts a background goroutine removing expired members every interval, one second if not
+// positive, so that they are released even if the leaderboard is not read. The returned function stops the sweeper
+func (lb *TypedLeaderboard[D]) StartSweeper(interval time.Duration) (stop func()) {
+	return startSweeper(interval, lb.expire)
+}
+
+// startSweeper calls sweep every interval, one second if not positive, in a background goroutine until the
+// returned function is called
+func startSweeper(interval time.Duration, sweep func()) (stop func()) {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				sweep()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+		})
+	}
+}
+
+// expiresAt gets the expiry time of a member updated at the given time, zero if the member never expires
+func (lb *TypedLeaderboard[D]) expiresAt(updatedAt time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		ttl = lb.config.MemberTTL
+	}
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return updatedAt.Add(ttl)
+}
+
+// expiryDue reports whether a member has expired. The caller must hold the lock
+func (lb *TypedLeaderboard[D]) expiryDue() bool {
+	if lb.frozen || len(lb.expiry.items) == 0 || lb.walError() != nil {
+		return false
+	}
+	return !lb.expiry.items[0].expiresAt.After(lb.now())
+}
+
+// purgeExpired removes the expired members. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) purgeExpired() {
+	if len(lb.expiry.items) == 0 {
+		return
+	}
+	lb.purgeExpiredAt(lb.now())
+}
+
+// purgeExpiredAt removes the members expired at the given time, frozen leaderboards keep them, and so do
+// leaderboards whose write-ahead log failed. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) purgeExpiredAt(now time.Time) {
+	if lb.frozen || lb.walError() != nil {
+		return
+	}
+
+	var expired []*expiryItem
+	var elements []*TypedElement[string, int64, TypedMemberData[D]]
+	for len(lb.expiry.items) > 0 && !lb.expiry.items[0].expiresAt.After(now) {
+		item := heap.Pop(lb.expiry).(*expiryItem)
+		expired = append(expired, item)
+		if element := lb.skipList.GetElementByMember(item.member); element != nil {
+			elements = append(elements, element)
+		}
+	}
+
+	// The expired members are removed at once, they are kept with their expiry time if the removal can't be logged
+	if err := lb.remove(elements); err != nil {
+		for _, item := range expired {
+			heap.Push(lb.expiry, item)
+		}
+	}
+}
+
+// expire removes the expired members, the write lock is only taken when a member has expired
+func (lb *TypedLeaderboard[D]) expire() {
+	lb.mutex.RLock()
+	due := lb.expiryDue()
+	lb.mutex.RUnlock()
+
+	if !due {
+		return
+	}
+
+	lb.mutex.Lock()
+	lb.purgeExpired()
+	lb.mutex.Unlock()
+}
+
+// lockRead removes the expired members, then takes the read lock
+func (lb *TypedLeaderboard[D]) lockRead() {
+	lb.expire()
+	lb.mutex.RLock()
+}
+
+// lockWrite takes the write lock, then removes the expired members
+func (lb *TypedLeaderboard[D]) lockWrite() {
+	lb.mutex.Lock()
+	lb.purgeExpired()
+}
@@ -0,0 +1,184 @@
+package rank
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardMemberTTL(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "ttl",
+		Name:         "TTL Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingDense,
+		MemberTTL:    10 * time.Second,
+	})
+
+	// Use a fake clock that only moves when the test advances it
+	now := time.Unix(1700000000, 0)
+	lb.now = func() time.Time {
+		return now
+	}
+
+	lb.Add("a", 100, nil)
+	lb.Add("b", 90, nil)
+	lb.Add("c", 80, nil)
+
+	member, _ := lb.GetMember("a")
+	if !member.ExpiresAt.Equal(now.Add(10 * time.Second)) {
+		t.Errorf("Expected a to expire at %v, got %v", now.Add(10*time.Second), member.ExpiresAt)
+	}
+
+	// Updating a member restarts its TTL
+	now = now.Add(5 * time.Second)
+	lb.Add("b", 95, nil)
+
+	now = now.Add(5 * time.Second)
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member after expiry, got %d", total)
+	}
+
+	if _, err := lb.GetRank("a"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected a to have expired, got %v", err)
+	}
+
+	rankList, _ := lb.GetRankList(1, 10)
+	if len(rankList) != 1 || rankList[0].Member != "b" || rankList[0].Rank != 1 {
+		t.Errorf("Expected only b at rank 1, got %v", rankList)
+	}
+
+	// Expired members don't count in dense ranks anymore
+	lb.Add("d", 100, nil)
+	if rank, _ := lb.GetRank("b"); rank != 2 {
+		t.Errorf("Expected rank 2, got %d", rank)
+	}
+
+	// Test a per-member TTL overriding the configuration
+	lb.AddWithTTL("e", 50, nil, time.Minute)
+	now = now.Add(30 * time.Second)
+
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member, got %d", total)
+	}
+	if _, err := lb.GetMember("e"); err != nil {
+		t.Errorf("Expected e not to have expired, got %v", err)
+	}
+
+	// Test per-member TTLs in batch writes
+	lb.AddMany([]ScoreUpdate[interface{}]{
+		{Member: "f", Score: 10, TTL: time.Second},
+		{Member: "g", Score: 20},
+	}, false)
+
+	now = now.Add(2 * time.Second)
+	if total := lb.GetTotal(); total != 2 {
+		t.Errorf("Expected e and g, got %d members", total)
+	}
+
+	// Removing a member stops tracking its expiry
+	lb.Remove("g")
+	now = now.Add(time.Hour)
+	if total := lb.GetTotal(); total != 0 {
+		t.Errorf("Expected no members, got %d", total)
+	}
+	if len(lb.expiry.items) != 0 || len(lb.expiry.members) != 0 {
+		t.Errorf("Expected an empty expiry queue, got %d items", len(lb.expiry.items))
+	}
+}
+
+func TestLeaderboardPerMemberTTL(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "per_member_ttl",
+		Name:         "Per Member TTL Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		MaxMembers:   2,
+	})
+
+	now := time.Unix(1700000000, 0)
+	lb.now = func() time.Time {
+		return now
+	}
+
+	lb.AddWithTTL("a", 100, nil, time.Second)
+	lb.Add("b", 90, nil)
+
+	// Without a configured TTL, a plain update makes the member permanent
+	lb.AddWithTTL("b", 90, nil, time.Second)
+	lb.Add("b", 90, nil)
+
+	now = now.Add(2 * time.Second)
+
+	// The expired member no longer takes up room in the full leaderboard
+	result, err := lb.Add("c", 10, nil)
+	if err != nil || result.Evicted != nil {
+		t.Errorf("Expected c to be added without eviction, got %+v, %v", result, err)
+	}
+
+	rankList, _ := lb.GetRankList(1, 10)
+	if len(rankList) != 2 || rankList[0].Member != "b" || rankList[1].Member != "c" {
+		t.Errorf("Expected b and c, got %v", rankList)
+	}
+}
+
+func TestLeaderboardSweeper(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "sweeper",
+		Name:         "Sweeper Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		MemberTTL:    time.Minute,
+	})
+
+	var mutex sync.Mutex
+	now := time.Unix(1700000000, 0)
+	lb.now = func() time.Time {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return now
+	}
+
+	for i := 0; i < 100; i++ {
+		lb.Add(string(rune('a'+i)), int64(i), nil)
+	}
+
+	stop := lb.StartSweeper(time.Millisecond)
+	defer stop()
+
+	mutex.Lock()
+	now = now.Add(time.Hour)
+	mutex.Unlock()
+
+	// The sweeper removes the members without any read
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		lb.mutex.RLock()
+		length := lb.skipList.Len()
+		lb.mutex.RUnlock()
+
+		if length == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the sweeper to remove all members, %d left", length)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Stopping twice is harmless
+	stop()
+}
+
+func TestSweeperDefaultInterval(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, MemberTTL: time.Minute})
+	w := NewWindowLeaderboard(WindowConfig{Leaderboard: LeaderboardConfig{ScoreOrder: true}})
+
+	// An interval that is not positive falls back to the default instead of panicking
+	for _, interval := range []time.Duration{0, -time.Second} {
+		lb.StartSweeper(interval)()
+		w.StartSweeper(interval)()
+	}
+}
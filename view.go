@@ -0,0 +1,206 @@
+package rank
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+)
+
+// Snapshot gets a read-only view of the leaderboard frozen at the current moment, so that paging through ranks or
+// paying out rewards sees a stable ordering while writes go on. Taking a view is O(1): the view shares the nodes
+// of the leaderboard, and a write only copies the links of the nodes it changes while a view still sees them, so a
+// view costs memory in proportion to the writes made while it is kept, which is released once the view is no longer
+// referenced. The view is read under the lock of the leaderboard, except for the loop bodies of its iterators.
+// Members expiring after the view was taken stay in the view, and the view is frozen
+func (lb *TypedLeaderboard[D]) Snapshot() Reader[D] {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	view := &TypedLeaderboard[D]{
+		config:   lb.config,
+		skipList: lb.skipList.view(),
+		expiry:   newExpiryQueue(),
+		frozen:   true,
+		view:     true,
+		mutex:    lb.mutex,
+		now:      lb.now,
+	}
+	if lb.scores != nil {
+		view.scores = lb.scores.view()
+	}
+
+	// Stop keeping what the view sees once it is gone
+	runtime.SetFinalizer(view, (*TypedLeaderboard[D]).release)
+
+	return view
+}
+
+// release stops keeping what a view sees in the skip lists it shares with its leaderboard
+func (lb *TypedLeaderboard[D]) release() {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	lb.skipList.release()
+	if lb.scores != nil {
+		lb.scores.release()
+	}
+}
+
+// history the epochs seen by the views of a skip list, and the links and nodes kept for them. Taking a view starts
+// a new epoch, and the links of a node are copied before they are first written in the new epoch, if a view still
+// sees them
+type history[K cmp.Ordered, S cmp.Ordered, D any] struct {
+	// epochs the epochs seen by the views, in ascending order
+	epochs []uint64
+	// kept the nodes with older links, each once
+	kept []*node[K, S, D]
+	// retired the unlinked nodes by member, the most recently unlinked first
+	retired map[K]*node[K, S, D]
+}
+
+// seen reports whether a view sees an epoch within [from, to)
+func (h *history[K, S, D]) seen(from, to uint64) bool {
+	i, _ := slices.BinarySearch(h.epochs, from)
+	return i < len(h.epochs) && h.epochs[i] < to
+}
+
+// view creates a read-only view of the skip list at the current moment, sharing its nodes. What the view sees is
+// kept until it is released, the skip list must be locked against writes
+func (sl *TypedSkipList[K, S, D]) view() *TypedSkipList[K, S, D] {
+	if sl.history == nil {
+		sl.history = &history[K, S, D]{retired: make(map[K]*node[K, S, D])}
+	}
+
+	view := *sl
+	sl.history.epochs = append(sl.history.epochs, sl.epoch)
+	sl.epoch++
+
+	return &view
+}
+
+// release stops keeping what a view sees, and drops the links and nodes no other view sees. The skip list the view
+// was taken from must be locked against writes
+func (sl *TypedSkipList[K, S, D]) release() {
+	h := sl.history
+	if i, ok := slices.BinarySearch(h.epochs, sl.epoch); ok {
+		h.epochs = slices.Delete(h.epochs, i, i+1)
+	}
+
+	// The links of a node written in an epoch are seen by the views from that epoch until the next write
+	kept := h.kept[:0]
+	for _, x := range h.kept {
+		for l := &x.nodeLinks; l.older != nil; {
+			if h.seen(l.older.epoch, l.epoch) {
+				l = l.older
+			} else {
+				l.older = l.older.older
+			}
+		}
+		if x.older != nil {
+			kept = append(kept, x)
+		}
+	}
+	clear(h.kept[len(kept):])
+	h.kept = kept
+
+	for member, first := range h.retired {
+		for link := &first; *link != nil; {
+			if x := *link; h.seen(x.created, x.removed) {
+				link = &x.prior
+			} else {
+				*link = x.prior
+			}
+		}
+		if first == nil {
+			delete(h.retired, member)
+		} else {
+			h.retired[member] = first
+		}
+	}
+}
+
+// seen reports whether a view still sees something written in an epoch
+func (sl *TypedSkipList[K, S, D]) seen(epoch uint64) bool {
+	return sl.history != nil && sl.history.seen(epoch, sl.epoch)
+}
+
+// shared reports whether views of the skip list may still see its nodes
+func (sl *TypedSkipList[K, S, D]) shared() bool {
+	return sl.history != nil && len(sl.history.epochs) > 0
+}
+
+// links gets the links of a node as seen by the skip list, a view sees the links the node had in its epoch
+func (sl *TypedSkipList[K, S, D]) links(x *node[K, S, D]) *nodeLinks[K, S, D] {
+	l := &x.nodeLinks
+	for l.epoch > sl.epoch {
+		l = l.older
+	}
+	return l
+}
+
+// next gets the next node and span of a node at a level as seen by the skip list
+func (sl *TypedSkipList[K, S, D]) next(x *node[K, S, D], level int) *levelNode[K, S, D] {
+	return sl.links(x).level[level]
+}
+
+// lookup gets the node of a member as seen by the skip list, nil if it doesn't exist
+func (sl *TypedSkipList[K, S, D]) lookup(member K) *node[K, S, D] {
+	if x, ok := sl.elementMap[member]; ok && x.created <= sl.epoch {
+		return x
+	}
+
+	// A view may see a node that has been unlinked since
+	if sl.history != nil {
+		for x := sl.history.retired[member]; x != nil; x = x.prior {
+			if x.created <= sl.epoch && sl.epoch < x.removed {
+				return x
+			}
+		}
+	}
+	return nil
+}
+
+// keep copies the links of a node before they are written, if a view still sees them
+func (sl *TypedSkipList[K, S, D]) keep(x *node[K, S, D]) {
+	if !sl.seen(x.epoch) {
+		return
+	}
+
+	// A node is in kept as long as it has older links
+	if x.older == nil {
+		sl.history.kept = append(sl.history.kept, x)
+	}
+
+	older := x.nodeLinks
+	older.level = make([]*levelNode[K, S, D], len(x.level))
+	for i, l := range x.level {
+		levelNode := *l
+		older.level[i] = &levelNode
+	}
+	x.older = &older
+	x.epoch = sl.epoch
+}
+
+// retire keeps a node being unlinked, if a view still sees it
+func (sl *TypedSkipList[K, S, D]) retire(x *node[K, S, D]) {
+	if !sl.seen(x.created) {
+		return
+	}
+
+	x.removed = sl.epoch
+	x.prior = sl.history.retired[x.element.Member]
+	sl.history.retired[x.element.Member] = x
+}
+
+// mutable gets the element of a member to change its data in place, nil if it doesn't exist. The node is replaced
+// first if a view still sees it
+func (sl *TypedSkipList[K, S, D]) mutable(member K) *TypedElement[K, S, D] {
+	x, ok := sl.elementMap[member]
+	if !ok {
+		return nil
+	}
+	if sl.seen(x.created) {
+		return sl.Insert(x.element.Member, x.element.Score, x.element.Data)
+	}
+	return &x.element
+}
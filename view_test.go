@@ -0,0 +1,421 @@
+package rank
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardSnapshotView(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{
+		ID:           "view",
+		Name:         "View Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+		RankingMode:  RankingDense,
+	})
+
+	for i := 1; i <= 300; i++ {
+		lb.Add(fmt.Sprintf("player%03d", i), int64(i/2), i)
+	}
+
+	view := lb.Snapshot()
+
+	// Page through the view while the leaderboard keeps changing
+	seen := make(map[string]bool)
+	for start := int64(1); start <= 300; start += 100 {
+		page, err := view.GetRankList(start, start+99)
+		if err != nil || len(page) != 100 {
+			t.Fatalf("Expected a page of 100 members, got %d, %v", len(page), err)
+		}
+		for _, rankData := range page {
+			if seen[rankData.Member] {
+				t.Errorf("Expected %s to be seen once", rankData.Member)
+			}
+			seen[rankData.Member] = true
+		}
+
+		lb.Add(fmt.Sprintf("new%d", start), 1000, nil)
+		lb.Remove(fmt.Sprintf("player%03d", 300-start))
+		lb.Incr("player001", 500, nil)
+	}
+	if len(seen) != 300 {
+		t.Errorf("Expected 300 distinct members, got %d", len(seen))
+	}
+
+	// The view keeps the standings it had when it was taken
+	if total := view.GetTotal(); total != 300 {
+		t.Errorf("Expected 300 members in the view, got %d", total)
+	}
+	if rankData, err := view.GetMemberAndRank("player001"); err != nil || rankData.Score != 0 || rankData.Rank != 151 {
+		t.Errorf("Expected player001 with 0 at dense rank 151 in the view, got %v %v", rankData, err)
+	}
+	if _, err := view.GetMember("new1"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected new1 to be absent from the view, got %v", err)
+	}
+	if rank := view.RankForScore(1000); rank != 1 {
+		t.Errorf("Expected rank 1 for 1000 in the view, got %d", rank)
+	}
+
+	// The leaderboard has all the writes
+	if total := lb.GetTotal(); total != 300 {
+		t.Errorf("Expected 300 members, got %d", total)
+	}
+	if rankData, err := lb.GetMemberAndRank("player001"); err != nil || rankData.Score != 1500 || rankData.Rank != 1 {
+		t.Errorf("Expected player001 with 1500 at rank 1, got %v %v", rankData, err)
+	}
+	if rank, _ := lb.GetRank("new1"); rank != 2 {
+		t.Errorf("Expected new1 at dense rank 2, got %d", rank)
+	}
+
+	// Range removals and resets don't reach the view either
+	second := lb.Snapshot()
+	lb.PopTop(10)
+	lb.RemoveScoreRange(0, 50)
+	if total := second.GetTotal(); total != 300 {
+		t.Errorf("Expected 300 members in the second view, got %d", total)
+	}
+	lb.Reset()
+	if total := second.GetTotal(); total != 300 {
+		t.Errorf("Expected 300 members in the second view after reset, got %d", total)
+	}
+	if total := view.GetTotal(); total != 300 {
+		t.Errorf("Expected 300 members in the first view, got %d", total)
+	}
+}
+
+func TestLeaderboardSnapshotViewTTL(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, MemberTTL: time.Minute})
+	now := time.Unix(1700000000, 0)
+	lb.now = func() time.Time {
+		return now
+	}
+
+	lb.Add("a", 1, nil)
+	lb.AddWithTTL("b", 2, nil, time.Hour)
+	view := lb.Snapshot()
+
+	// Members expiring after the view was taken stay in the view
+	now = now.Add(2 * time.Minute)
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member after expiry, got %d", total)
+	}
+	if total := view.GetTotal(); total != 2 {
+		t.Errorf("Expected 2 members in the view, got %d", total)
+	}
+	if rank, err := view.GetRank("a"); err != nil || rank != 2 {
+		t.Errorf("Expected a at rank 2 in the view, got %d %v", rank, err)
+	}
+}
+
+func TestLeaderboardSnapshotViewConcurrent(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways})
+	for i := 0; i < 1000; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			lb.Incr(fmt.Sprintf("player%d", i), 1000, nil)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			view := lb.Snapshot()
+			total := view.GetTotal()
+
+			// Every page of a view is consistent with the others
+			var count uint64
+			var last int64 = 1 << 62
+			for start := int64(1); start <= int64(total); start += 100 {
+				page, _ := view.GetRankList(start, start+99)
+				for _, rankData := range page {
+					if rankData.Score > last {
+						t.Errorf("Expected descending scores, got %d after %d", rankData.Score, last)
+					}
+					last = rankData.Score
+					count++
+				}
+			}
+			if count != total {
+				t.Errorf("Expected %d members over all pages, got %d", total, count)
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+func TestLeaderboardSnapshotViewWrites(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways})
+
+	members := 1000
+	scores := make([]int64, members)
+	for i := range scores {
+		scores[i] = int64(i)
+		lb.Add(fmt.Sprintf("player%d", i), scores[i], nil)
+	}
+	var sum int64
+	for _, score := range scores {
+		sum += score
+	}
+
+	// Every write moves points between two members at once, so that the total never changes
+	started := make(chan struct{})
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if i == 100 {
+				close(started)
+			}
+			select {
+			case <-done:
+				return
+			default:
+			}
+			from, to := (i*7919)%members, (i*104729+1)%members
+			if from == to {
+				continue
+			}
+			scores[from] -= 5000
+			scores[to] += 5000
+			lb.AddMany([]ScoreUpdate[interface{}]{
+				{Member: fmt.Sprintf("player%d", from), Score: scores[from]},
+				{Member: fmt.Sprintf("player%d", to), Score: scores[to]},
+			}, false)
+		}
+	}()
+
+	<-started
+	for i := 0; i < 5; i++ {
+		view := lb.Snapshot()
+		if total := view.GetTotal(); total != uint64(members) {
+			t.Errorf("Expected %d members in the view, got %d", members, total)
+		}
+
+		page, _ := view.GetRankList(1, int64(members))
+		var total int64
+		seen := make(map[string]bool)
+		for j, rankData := range page {
+			if seen[rankData.Member] {
+				t.Errorf("Expected %s to be seen once", rankData.Member)
+			}
+			seen[rankData.Member] = true
+			if j > 0 && rankData.Score > page[j-1].Score {
+				t.Errorf("Expected descending scores, got %d after %d", rankData.Score, page[j-1].Score)
+			}
+			total += rankData.Score
+		}
+		if total != sum {
+			t.Errorf("Expected a total score of %d in the view, got %d", sum, total)
+		}
+	}
+
+	close(done)
+	<-stopped
+}
+
+func TestLeaderboardSnapshotViewSharing(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways})
+	for i := 0; i < 10000; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+	}
+
+	// Taking a view copies nothing
+	view := lb.Snapshot()
+	if kept := len(lb.skipList.history.kept); kept != 0 {
+		t.Errorf("Expected no links to be kept for a view, got %d nodes", kept)
+	}
+
+	// A write only copies the links of the nodes it changes
+	lb.Add("player0", 100000, nil)
+	if kept := len(lb.skipList.history.kept); kept == 0 || kept > 2*MaxLevel {
+		t.Errorf("Expected the links of a few nodes to be kept for a write, got %d nodes", kept)
+	}
+	if rank, err := view.GetRank("player0"); err != nil || rank != 10000 {
+		t.Errorf("Expected player0 at rank 10000 in the view, got %d %v", rank, err)
+	}
+
+	// Writing the same nodes again in the same epoch doesn't copy them again
+	kept := len(lb.skipList.history.kept)
+	lb.Add("player0", 100001, nil)
+	if len(lb.skipList.history.kept) > kept+2*MaxLevel {
+		t.Errorf("Expected few more nodes to be kept, got %d after %d", len(lb.skipList.history.kept), kept)
+	}
+}
+
+func TestLeaderboardSnapshotViewHistory(t *testing.T) {
+	for _, mode := range []RankingMode{RankingOrdinal, RankingDense} {
+		lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways, RankingMode: mode})
+		rng := rand.New(rand.NewSource(1))
+
+		// Every view must keep the standings the leaderboard had when it was taken
+		type taken struct {
+			view   Reader[interface{}]
+			want   []*RankData
+			probes []int64
+		}
+		var views []taken
+		check := func() {
+			t.Helper()
+			for _, v := range views {
+				got, _ := v.view.GetRankList(1, int64(len(v.want))+1)
+				if len(got) != len(v.want) {
+					t.Fatalf("Expected %d members in the view, got %d", len(v.want), len(got))
+				}
+				for i, rankData := range got {
+					want := v.want[i]
+					if rankData.Member != want.Member || rankData.Score != want.Score || rankData.Rank != want.Rank {
+						t.Fatalf("Expected %s with %d at rank %d, got %s with %d at rank %d", want.Member, want.Score, want.Rank, rankData.Member, rankData.Score, rankData.Rank)
+					}
+					if member, err := v.view.GetMemberAndRank(want.Member); err != nil || member.Rank != want.Rank {
+						t.Fatalf("Expected %s at rank %d, got %v %v", want.Member, want.Rank, member, err)
+					}
+					if rank := v.view.RankForMemberScore(want.Member, want.Score-1); rank != v.probes[i] {
+						t.Fatalf("Expected rank %d for %s with %d, got %d", v.probes[i], want.Member, want.Score-1, rank)
+					}
+				}
+			}
+		}
+		release := func(i int) {
+			view := views[i].view.(*Leaderboard)
+			runtime.SetFinalizer(view, nil)
+			view.release()
+			views = append(views[:i], views[i+1:]...)
+		}
+
+		for round := 0; round < 2000; round++ {
+			member := fmt.Sprintf("player%d", rng.Intn(300))
+			switch op := rng.Intn(20); {
+			case op < 10:
+				lb.Add(member, rng.Int63n(100), nil)
+			case op < 14:
+				lb.Remove(member)
+			case op < 15:
+				lb.PopTop(int64(rng.Intn(5)))
+			case op < 16:
+				score := rng.Int63n(100)
+				lb.RemoveScoreRange(score, score+2)
+			case op < 18:
+				want, _ := lb.GetRankList(1, int64(lb.GetTotal()))
+				probes := make([]int64, 0, len(want))
+				for _, rankData := range want {
+					probes = append(probes, lb.RankForMemberScore(rankData.Member, rankData.Score-1))
+				}
+				views = append(views, taken{view: lb.Snapshot(), want: want, probes: probes})
+			default:
+				if len(views) > 0 {
+					release(rng.Intn(len(views)))
+				}
+			}
+			if round%50 == 0 {
+				check()
+			}
+		}
+		check()
+
+		// Nothing is kept once all the views are released
+		for len(views) > 0 {
+			release(0)
+		}
+		if kept := keptNodes(lb.skipList) + keptNodes(lb.scores); kept != 0 {
+			t.Errorf("Expected nothing to be kept after the views are released, got %d", kept)
+		}
+	}
+}
+
+// keptNodes counts the nodes and links kept in the history of a skip list
+func keptNodes[K cmp.Ordered, S cmp.Ordered, D any](sl *TypedSkipList[K, S, D]) int {
+	if sl == nil || sl.history == nil {
+		return 0
+	}
+
+	kept := len(sl.history.kept) + len(sl.history.retired)
+	for x := sl.head; x != nil; x = x.level[0].forward {
+		if x.older != nil {
+			kept++
+		}
+	}
+	return kept
+}
+
+func TestLeaderboardSnapshotViewIterate(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways})
+	for i := 0; i < 100; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+	}
+
+	// The loop body of a view may write to the leaderboard the view was taken from
+	view := lb.Snapshot()
+	count := 0
+	for rank, rankData := range view.All() {
+		if rankData.Score != int64(100-rank) {
+			t.Errorf("Expected %d at rank %d, got %d", 100-rank, rank, rankData.Score)
+		}
+		lb.Incr(rankData.Member, 1000, nil)
+		count++
+	}
+	if count != 100 {
+		t.Errorf("Expected 100 members, got %d", count)
+	}
+	if rank, _ := lb.GetRank("player0"); rank != 100 {
+		t.Errorf("Expected player0 at rank 100, got %d", rank)
+	}
+}
+
+func TestLeaderboardSnapshotViewRelease(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, UpdatePolicy: UpdateAlways})
+	for i := 0; i < 100; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+	}
+
+	lb.Snapshot()
+	for i := 0; i < 100; i++ {
+		lb.Incr(fmt.Sprintf("player%d", i), 1000, nil)
+	}
+
+	// What an unreferenced view sees is released by the garbage collector
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.GC()
+		lb.mutex.RLock()
+		shared, kept := lb.skipList.shared(), keptNodes(lb.skipList)
+		lb.mutex.RUnlock()
+
+		if !shared && kept == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the view to be released, %d nodes kept", kept)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLeaderboardSnapshotViewUnfreeze(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	lb.Add("a", 1, nil)
+
+	// A view shares the nodes of its leaderboard, so it can't be unfrozen
+	view := lb.Snapshot().(*Leaderboard)
+	view.Unfreeze()
+	if !view.IsFrozen() {
+		t.Error("Expected the view to stay frozen")
+	}
+	if _, err := view.Add("b", 2, nil); !errors.Is(err, ErrFrozen) {
+		t.Errorf("Expected ErrFrozen, got %v", err)
+	}
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member, got %d", total)
+	}
+}
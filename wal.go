@@ -0,0 +1,473 @@
+package rank
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SyncPolicy policy for flushing the write-ahead log to stable storage
+type SyncPolicy int
+
+const (
+	// SyncAlways every write is flushed to stable storage before it returns
+	SyncAlways SyncPolicy = iota
+	// SyncInterval writes are flushed to stable storage every SyncInterval, a crash of the machine may lose
+	// the writes of the last interval
+	SyncInterval
+	// SyncNever writes are left to the operating system, a crash of the machine may lose any write
+	// that was not flushed by the operating system yet
+	SyncNever
+)
+
+// WALConfig write-ahead log configuration
+type WALConfig struct {
+	// Dir directory holding the snapshot and the log
+	Dir string
+	// Sync policy for flushing the log to stable storage
+	Sync SyncPolicy
+	// SyncInterval time between two flushes with SyncInterval, one second if not positive
+	SyncInterval time.Duration
+	// CompactThreshold size of the log in bytes after which it is compacted into a new snapshot, 0 to never compact
+	CompactThreshold int64
+}
+
+// File names in the write-ahead log directory
+const (
+	walSnapshotFile = "leaderboard.snapshot"
+	walLogFile      = "leaderboard.wal"
+)
+
+// Write-ahead log record types
+const (
+	walSet uint64 = iota + 1
+	walDelete
+	walReset
+)
+
+// writeAheadLog log of the writes of a leaderboard since its last snapshot
+type writeAheadLog struct {
+	config WALConfig
+	file   *os.File
+	size   int64
+	// mutex guards dirty and err, which are shared with the sync goroutine
+	mutex sync.Mutex
+	dirty bool
+	err   error
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// OpenWAL restores the leaderboard from the snapshot and the write-ahead log in the directory of the configuration,
+// then logs every following write. Members, the configuration except the Codec, and the previous write-ahead log
+// if any are replaced. Once a write fails to be logged, all writes fail with AddFailed until the log is reopened
+func (lb *TypedLeaderboard[D]) OpenWAL(config WALConfig) error {
+	if config.SyncInterval <= 0 {
+		config.SyncInterval = time.Second
+	}
+
+	// The lock is held until the log is attached, so that no write is applied in between and lost
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	if lb.frozen {
+		return ErrFrozen
+	}
+
+	if err := lb.closeWAL(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return err
+	}
+
+	// Restore the latest snapshot
+	snapshot, err := os.Open(filepath.Join(config.Dir, walSnapshotFile))
+	switch {
+	case err == nil:
+		snapshotConfig, skipList, err := readSnapshot[D](snapshot, lb.codec())
+		snapshot.Close()
+		if err != nil {
+			return err
+		}
+		lb.load(snapshotConfig, skipList)
+	case errors.Is(err, os.ErrNotExist):
+		lb.reset()
+	default:
+		return err
+	}
+
+	file, err := os.OpenFile(filepath.Join(config.Dir, walLogFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+
+	// Replay the writes logged since the snapshot, a torn record at the end of the log is dropped
+	size, err := lb.replay(file)
+	if err == nil {
+		err = file.Truncate(size)
+	}
+	if err == nil {
+		_, err = file.Seek(size, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	wal := &writeAheadLog{
+		config: config,
+		file:   file,
+		size:   size,
+		done:   make(chan struct{}),
+	}
+	if config.Sync == SyncInterval {
+		wal.wg.Add(1)
+		go wal.syncLoop()
+	}
+	lb.wal = wal
+
+	return nil
+}
+
+// CloseWAL flushes and detaches the write-ahead log, the following writes are no longer durable.
+// It returns the error that stopped the writes, if any
+func (lb *TypedLeaderboard[D]) CloseWAL() error {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	return lb.closeWAL()
+}
+
+// closeWAL flushes and detaches the write-ahead log. The caller must hold the write lock, which the sync
+// goroutine doesn't need to stop
+func (lb *TypedLeaderboard[D]) closeWAL() error {
+	wal := lb.wal
+	lb.wal = nil
+
+	if wal == nil {
+		return nil
+	}
+
+	close(wal.done)
+	wal.wg.Wait()
+
+	err := wal.sync()
+	if closeErr := wal.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Compact writes a new snapshot of the leaderboard and empties the write-ahead log
+func (lb *TypedLeaderboard[D]) Compact() error {
+	lb.mutex.Lock()
+	defer lb.mutex.Unlock()
+
+	if lb.wal == nil {
+		return nil
+	}
+	return lb.compact()
+}
+
+// compact writes a new snapshot of the leaderboard and empties the write-ahead log. Replaying the log on top of
+// the new snapshot gives the same members, so a crash between the two steps loses nothing.
+// The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) compact() error {
+	wal := lb.wal
+	path := filepath.Join(wal.config.Dir, walSnapshotFile)
+
+	// Write the new snapshot aside, then replace the previous one atomically
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return wal.fail(err)
+	}
+	err = lb.saveSnapshot(file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err == nil {
+		err = syncDir(wal.config.Dir)
+	}
+	if err != nil {
+		return wal.fail(err)
+	}
+
+	if err := wal.file.Truncate(0); err != nil {
+		return wal.fail(err)
+	}
+	if _, err := wal.file.Seek(0, io.SeekStart); err != nil {
+		return wal.fail(err)
+	}
+	wal.size = 0
+
+	return nil
+}
+
+// walError gets the error that stopped the writes, if any. The caller must hold the lock
+func (lb *TypedLeaderboard[D]) walError() error {
+	if lb.wal == nil {
+		return nil
+	}
+
+	lb.wal.mutex.Lock()
+	defer lb.wal.mutex.Unlock()
+
+	return lb.wal.err
+}
+
+// setRecord encodes the record of the new state of a member, nil without a write-ahead log.
+// The caller must hold the lock
+func (lb *TypedLeaderboard[D]) setRecord(memberData TypedMemberData[D]) ([]byte, error) {
+	if lb.wal == nil {
+		return nil, nil
+	}
+
+	data, err := lb.codec().Marshal(memberData.Data)
+	if err != nil {
+		return nil, fmt.Errorf("encode data of member %s: %w", memberData.Member, err)
+	}
+
+	return encodeRecord(func(rw *snapshotWriter) {
+		rw.writeUvarint(walSet)
+		rw.writeString(memberData.Member)
+		rw.writeVarint(memberData.Score)
+		rw.writeTime(memberData.UpdatedAt)
+		rw.writeTime(memberData.ExpiresAt)
+		rw.writeBytes(data)
+	})
+}
+
+// logDelete logs the removal of members in a single record. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) logDelete(elements []*TypedElement[string, int64, TypedMemberData[D]]) error {
+	if lb.wal == nil {
+		return nil
+	}
+
+	return lb.log(func(rw *snapshotWriter) {
+		rw.writeUvarint(walDelete)
+		rw.writeUvarint(uint64(len(elements)))
+		for _, element := range elements {
+			rw.writeString(element.Member)
+		}
+	})
+}
+
+// logReset logs the removal of all members. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) logReset() error {
+	if lb.wal == nil {
+		return nil
+	}
+
+	return lb.log(func(rw *snapshotWriter) {
+		rw.writeUvarint(walReset)
+	})
+}
+
+// log appends a record to the write-ahead log, before the write is applied. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) log(write func(rw *snapshotWriter)) error {
+	record, err := encodeRecord(write)
+	if err != nil {
+		return err
+	}
+	return lb.appendRecord(record)
+}
+
+// encodeRecord encodes a write-ahead log record, framed by its length and ending with its own checksum
+func encodeRecord(write func(rw *snapshotWriter)) ([]byte, error) {
+	var record bytes.Buffer
+	record.Write(make([]byte, 4))
+	rw := newSnapshotWriter(&record)
+	write(rw)
+	if err := rw.close(); err != nil {
+		return nil, err
+	}
+	binary.BigEndian.PutUint32(record.Bytes()[:4], uint32(record.Len()-4))
+
+	return record.Bytes(), nil
+}
+
+// appendRecord appends an encoded record to the write-ahead log, if any. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) appendRecord(record []byte) error {
+	wal := lb.wal
+	if wal == nil {
+		return nil
+	}
+	if err := lb.walError(); err != nil {
+		return err
+	}
+
+	if _, err := wal.file.Write(record); err != nil {
+		return wal.fail(err)
+	}
+	wal.size += int64(len(record))
+
+	wal.mutex.Lock()
+	wal.dirty = true
+	wal.mutex.Unlock()
+
+	if wal.config.Sync == SyncAlways {
+		return wal.sync()
+	}
+
+	return nil
+}
+
+// maybeCompact compacts the write-ahead log once it is too large, it must be called after the logged write
+// is applied. The error is kept for the following writes. The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) maybeCompact() {
+	if lb.wal == nil || lb.wal.config.CompactThreshold <= 0 || lb.wal.size < lb.wal.config.CompactThreshold {
+		return
+	}
+	lb.compact()
+}
+
+// replay applies the records of a write-ahead log, and returns the size of the valid part of the log.
+// The caller must hold the write lock
+func (lb *TypedLeaderboard[D]) replay(file *os.File) (int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	codec := lb.codec()
+	r := bufio.NewReader(file)
+	var size int64
+
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			// The end of the log, or a torn record
+			return size, nil
+		}
+
+		// Don't trust the length for the allocation, a corrupted length reaches the end of the log anyway
+		length := int64(binary.BigEndian.Uint32(header[:]))
+		body, err := io.ReadAll(io.LimitReader(r, length))
+		if err != nil {
+			return size, err
+		}
+		if int64(len(body)) != length {
+			// A torn record
+			return size, nil
+		}
+
+		// Records are checked before being applied, the log ends at the first invalid record
+		sr := newSnapshotReader(bytes.NewReader(body))
+		op := sr.readUvarint()
+		switch op {
+		case walSet:
+			memberData := TypedMemberData[D]{
+				Member:    sr.readString(),
+				Score:     sr.readVarint(),
+				UpdatedAt: sr.readTime(),
+				ExpiresAt: sr.readTime(),
+			}
+			data := sr.readBytes()
+			if sr.close() != nil {
+				return size, nil
+			}
+			if err := codec.Unmarshal(data, &memberData.Data); err != nil {
+				return size, fmt.Errorf("%w: decode data of member %s: %v", ErrInvalidSnapshot, memberData.Member, err)
+			}
+			lb.insert(memberData)
+		case walDelete:
+			count := sr.readUvarint()
+			members := make([]string, 0, min(count, 1024))
+			for i := uint64(0); i < count && sr.err == nil; i++ {
+				members = append(members, sr.readString())
+			}
+			if sr.close() != nil {
+				return size, nil
+			}
+			for _, member := range members {
+				if element := lb.skipList.GetElementByMember(member); element != nil {
+					lb.delete(element)
+				}
+			}
+		case walReset:
+			if sr.close() != nil {
+				return size, nil
+			}
+			lb.reset()
+		default:
+			return size, nil
+		}
+
+		size += int64(len(header) + len(body))
+	}
+}
+
+// sync flushes the log to stable storage if it has unflushed writes
+func (wal *writeAheadLog) sync() error {
+	wal.mutex.Lock()
+	defer wal.mutex.Unlock()
+
+	if !wal.dirty || wal.err != nil {
+		return wal.err
+	}
+
+	if err := wal.file.Sync(); err != nil {
+		wal.err = err
+		return err
+	}
+	wal.dirty = false
+
+	return nil
+}
+
+// syncLoop flushes the log every sync interval until the log is closed
+func (wal *writeAheadLog) syncLoop() {
+	defer wal.wg.Done()
+
+	ticker := time.NewTicker(wal.config.SyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			wal.sync()
+		case <-wal.done:
+			return
+		}
+	}
+}
+
+// fail keeps the first error, which stops all the following writes
+func (wal *writeAheadLog) fail(err error) error {
+	wal.mutex.Lock()
+	defer wal.mutex.Unlock()
+
+	if wal.err == nil {
+		wal.err = err
+	}
+	return wal.err
+}
+
+// syncDir flushes a directory to stable storage, so that a rename in it is durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	// Some platforms don't support syncing directories
+	if err := d.Sync(); err != nil && !errors.Is(err, os.ErrInvalid) {
+		return err
+	}
+	return nil
+}
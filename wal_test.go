@@ -0,0 +1,356 @@
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLeaderboardWAL(t *testing.T) {
+	dir := t.TempDir()
+	config := LeaderboardConfig{
+		ID:           "wal",
+		Name:         "WAL Test",
+		ScoreOrder:   true,
+		UpdatePolicy: UpdateAlways,
+	}
+
+	for _, sync := range []SyncPolicy{SyncAlways, SyncInterval, SyncNever} {
+		t.Run(fmt.Sprintf("sync%d", sync), func(t *testing.T) {
+			dir := filepath.Join(dir, fmt.Sprintf("sync%d", sync))
+
+			lb := NewLeaderboard(config)
+			if err := lb.OpenWAL(WALConfig{Dir: dir, Sync: sync, SyncInterval: time.Millisecond}); err != nil {
+				t.Fatalf("Failed to open WAL: %v", err)
+			}
+
+			for i := 0; i < 100; i++ {
+				lb.Add(fmt.Sprintf("player%d", i), int64(i), fmt.Sprintf("data%d", i))
+			}
+			lb.Remove("player50")
+			lb.Incr("player0", 1000, "top")
+			lb.RemoveScoreRange(10, 19)
+
+			if err := lb.CloseWAL(); err != nil {
+				t.Fatalf("Failed to close WAL: %v", err)
+			}
+
+			// Writes after closing are not logged
+			lb.Add("late", 1, nil)
+
+			// Without a snapshot, the configuration of the restored leaderboard is kept
+			restored := NewLeaderboard(config)
+			if err := restored.OpenWAL(WALConfig{Dir: dir, Sync: sync}); err != nil {
+				t.Fatalf("Failed to reopen WAL: %v", err)
+			}
+			defer restored.CloseWAL()
+
+			if total := restored.GetTotal(); total != 89 {
+				t.Errorf("Expected 89 members, got %d", total)
+			}
+
+			top, err := restored.GetMemberAndRank("player0")
+			if err != nil || top.Rank != 1 || top.Score != 1000 || top.Data != "top" {
+				t.Errorf("Expected player0 at rank 1 with 1000 and its data, got %v %v", top, err)
+			}
+
+			for _, member := range []string{"player50", "player15", "late"} {
+				if _, err := restored.GetMember(member); !errors.Is(err, ErrMemberNotFound) {
+					t.Errorf("Expected %s to be absent, got %v", member, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLeaderboardWALReset(t *testing.T) {
+	dir := t.TempDir()
+
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := lb.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	lb.Add("a", 1, nil)
+	lb.Reset()
+	lb.Add("b", 2, nil)
+	lb.CloseWAL()
+
+	restored := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer restored.CloseWAL()
+
+	rankList, _ := restored.GetRankList(1, 10)
+	if len(rankList) != 1 || rankList[0].Member != "b" {
+		t.Errorf("Expected only b, got %v", rankList)
+	}
+}
+
+func TestLeaderboardWALTornTail(t *testing.T) {
+	dir := t.TempDir()
+
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := lb.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	lb.Add("a", 1, nil)
+	lb.Add("b", 2, nil)
+	lb.CloseWAL()
+
+	// Simulate a crash in the middle of the last record
+	path := filepath.Join(dir, walLogFile)
+	info, _ := os.Stat(path)
+	if err := os.Truncate(path, info.Size()-3); err != nil {
+		t.Fatalf("Failed to truncate log: %v", err)
+	}
+
+	restored := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+
+	if total := restored.GetTotal(); total != 1 {
+		t.Errorf("Expected the torn record to be dropped, got %d members", total)
+	}
+
+	// The torn record is cut off, so new records follow the last valid one
+	restored.Add("c", 3, nil)
+	restored.CloseWAL()
+
+	again := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := again.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer again.CloseWAL()
+
+	rankList, _ := again.GetRankList(1, 10)
+	if len(rankList) != 2 || rankList[0].Member != "c" || rankList[1].Member != "a" {
+		t.Errorf("Expected c and a, got %v", rankList)
+	}
+}
+
+func TestLeaderboardWALCompact(t *testing.T) {
+	dir := t.TempDir()
+	config := LeaderboardConfig{ID: "compact", ScoreOrder: true, UpdatePolicy: UpdateAlways}
+
+	lb := NewLeaderboard(config)
+	if err := lb.OpenWAL(WALConfig{Dir: dir, Sync: SyncNever, CompactThreshold: 1024}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+
+	for i := 0; i < 1000; i++ {
+		lb.Add(fmt.Sprintf("player%d", i%50), int64(i), nil)
+	}
+
+	// The log is compacted into a snapshot whenever it grows past the threshold
+	if _, err := os.Stat(filepath.Join(dir, walSnapshotFile)); err != nil {
+		t.Errorf("Expected a snapshot, got %v", err)
+	}
+	if info, _ := os.Stat(filepath.Join(dir, walLogFile)); info.Size() >= 1024 {
+		t.Errorf("Expected the log to be compacted, got %d bytes", info.Size())
+	}
+
+	lb.Remove("player0")
+	if err := lb.Compact(); err != nil {
+		t.Fatalf("Failed to compact: %v", err)
+	}
+	if info, _ := os.Stat(filepath.Join(dir, walLogFile)); info.Size() != 0 {
+		t.Errorf("Expected an empty log after compaction, got %d bytes", info.Size())
+	}
+	lb.Add("player1", 5000, nil)
+	lb.CloseWAL()
+
+	restored := NewLeaderboard(LeaderboardConfig{})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer restored.CloseWAL()
+
+	if restored.config.ID != "compact" {
+		t.Errorf("Expected the configuration from the snapshot, got %+v", restored.config)
+	}
+
+	expected, _ := lb.GetRankList(1, 100)
+	actual, _ := restored.GetRankList(1, 100)
+	if len(actual) != 49 || len(actual) != len(expected) {
+		t.Fatalf("Expected %d members, got %d", len(expected), len(actual))
+	}
+	for i := range expected {
+		if actual[i].Member != expected[i].Member || actual[i].Score != expected[i].Score {
+			t.Errorf("Expected %v at rank %d, got %v", expected[i], i+1, actual[i])
+		}
+	}
+}
+
+func TestLeaderboardWALFailure(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := lb.OpenWAL(WALConfig{Dir: t.TempDir()}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	lb.Add("a", 1, nil)
+
+	// Once the log can't be written, writes fail instead of being lost silently
+	lb.wal.file.Close()
+
+	result, err := lb.Add("b", 2, nil)
+	if err == nil || result.Status != AddFailed || !result.IsNew {
+		t.Errorf("Expected the write to fail, got %+v", result)
+	}
+
+	result, err = lb.Add("a", 10, nil)
+	if err == nil || result.Status != AddFailed || result.Score != 1 || result.Rank != 1 {
+		t.Errorf("Expected the write to fail with the current standing, got %+v", result)
+	}
+
+	if total := lb.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member, got %d", total)
+	}
+
+	if err := lb.CloseWAL(); err == nil {
+		t.Errorf("Expected the write error when closing")
+	}
+
+	// Without a log, writes are accepted again
+	if result, err := lb.Add("b", 2, nil); err != nil || result.Status != AddApplied {
+		t.Errorf("Expected the write to succeed, got %+v", result)
+	}
+}
+
+func TestLeaderboardWALEviction(t *testing.T) {
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true, MaxMembers: 2})
+	if err := lb.OpenWAL(WALConfig{Dir: t.TempDir()}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	defer lb.CloseWAL()
+
+	lb.Add("a", 1, nil)
+	lb.Add("b", 2, nil)
+
+	// A write that can't be logged doesn't evict anyone
+	result, err := lb.Add("c", 3, make(chan int))
+	if err == nil || result.Status != AddFailed || result.Evicted != nil {
+		t.Errorf("Expected the write to fail without eviction, got %+v", result)
+	}
+
+	if total := lb.GetTotal(); total != 2 {
+		t.Errorf("Expected 2 members, got %d", total)
+	}
+	if _, err := lb.GetMember("a"); err != nil {
+		t.Errorf("Expected a to be kept, got %v", err)
+	}
+
+	// An unencodable payload doesn't stop the following writes
+	if result, err := lb.Add("c", 3, nil); err != nil || result.Evicted == nil || result.Evicted.Member != "a" {
+		t.Errorf("Expected c to evict a, got %+v %v", result, err)
+	}
+}
+
+func TestLeaderboardWALRemoveFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := lb.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+	}
+
+	// Once the log can't be written, removals fail instead of being lost on reopen
+	lb.wal.file.Close()
+
+	if removed, err := lb.Remove("player1"); err == nil || removed {
+		t.Errorf("Expected the removal to fail, got %v %v", removed, err)
+	}
+	if removed, err := lb.RemoveMany([]string{"player2", "player3"}); err == nil || removed != 0 {
+		t.Errorf("Expected no member removed, got %d %v", removed, err)
+	}
+	if removed, err := lb.RemoveScoreRange(1, 5); err == nil || removed != 0 {
+		t.Errorf("Expected no member removed, got %d %v", removed, err)
+	}
+	if popped, err := lb.PopTop(2); err == nil || len(popped) != 0 {
+		t.Errorf("Expected no member popped, got %v %v", popped, err)
+	}
+	if err := lb.Reset(); err == nil {
+		t.Errorf("Expected the reset to fail")
+	}
+
+	if total := lb.GetTotal(); total != 5 {
+		t.Errorf("Expected 5 members, got %d", total)
+	}
+	lb.CloseWAL()
+
+	restored := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer restored.CloseWAL()
+
+	if total := restored.GetTotal(); total != 5 {
+		t.Errorf("Expected 5 members, got %d", total)
+	}
+}
+
+func TestLeaderboardWALCorruptLength(t *testing.T) {
+	dir := t.TempDir()
+
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := lb.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	lb.Add("a", 1, nil)
+	lb.CloseWAL()
+
+	// A corrupted length is treated as a torn record instead of being allocated
+	file, err := os.OpenFile(filepath.Join(dir, walLogFile), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	file.Write([]byte{0xff, 0xff, 0xff, 0xff, 1, 2, 3})
+	file.Close()
+
+	restored := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer restored.CloseWAL()
+
+	if total := restored.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member, got %d", total)
+	}
+}
+
+func TestLeaderboardWALConcurrentOpen(t *testing.T) {
+	dir := t.TempDir()
+
+	lb := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			lb.Add(fmt.Sprintf("player%d", i), int64(i), nil)
+		}
+	}()
+
+	if err := lb.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to open WAL: %v", err)
+	}
+	<-done
+	total := lb.GetTotal()
+	lb.CloseWAL()
+
+	// Every write kept in memory is either replaced by the restored state or logged
+	restored := NewLeaderboard(LeaderboardConfig{ScoreOrder: true})
+	if err := restored.OpenWAL(WALConfig{Dir: dir}); err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer restored.CloseWAL()
+
+	if actual := restored.GetTotal(); actual != total {
+		t.Errorf("Expected %d members, got %d", total, actual)
+	}
+}
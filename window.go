@@ -0,0 +1,403 @@
+package rank
+
+import (
+	"iter"
+	"sync"
+	"time"
+)
+
+// Aggregation method for combining the score events of a member within the window
+type Aggregation int
+
+const (
+	// AggregateSum the score of a member is the sum of its events
+	AggregateSum Aggregation = iota
+	// AggregateMax the score of a member is the highest score of its events
+	AggregateMax
+	// AggregateMin the score of a member is the lowest score of its events
+	AggregateMin
+)
+
+// WindowConfig sliding window leaderboard configuration
+type WindowConfig struct {
+	// Leaderboard configuration of the ranking of the aggregated scores,
+	// UpdatePolicy, MaxMembers and MemberTTL are ignored
+	Leaderboard LeaderboardConfig
+	// Window length of the sliding window, score events older than the window no longer count, one day if not positive
+	Window time.Duration
+	// Aggregation method for combining the score events of a member
+	Aggregation Aggregation
+}
+
+// WindowLeaderboard leaderboard ranking the score events recorded within a sliding window, such as the last 7 days.
+// Members are ranked by the aggregate of their events, which is updated as events age out of the window,
+// and members without any event left in the window are removed
+type WindowLeaderboard[D any] struct {
+	// config configuration information
+	config WindowConfig
+	// leaderboard aggregated scores
+	leaderboard *TypedLeaderboard[D]
+	// events score events within the window, oldest first
+	events []windowEvent
+	// members aggregation state of the members with events within the window
+	members map[string]*windowMember[D]
+	// seq sequence number of the last recorded event
+	seq uint64
+	// mutex mutex for thread safety
+	mutex sync.RWMutex
+	// now returns the current time, used for the event times
+	now func() time.Time
+}
+
+// windowEvent a score event
+type windowEvent struct {
+	seq    uint64
+	member string
+	score  int64
+	at     time.Time
+}
+
+// windowMember aggregation state of a member
+type windowMember[D any] struct {
+	// first sequence number of the first event of the member, older events belong to a removed member
+	first uint64
+	// count number of events within the window
+	count int
+	// sum sum of the scores of the events
+	sum int64
+	// extremes candidates for the max or min score, oldest first, the first one is the current extreme
+	extremes []windowEvent
+	// data additional data of the latest event
+	data D
+}
+
+// NewWindowLeaderboard creates a new sliding window leaderboard with untyped additional data
+func NewWindowLeaderboard(config WindowConfig) *WindowLeaderboard[interface{}] {
+	return NewTypedWindowLeaderboard[interface{}](config)
+}
+
+// NewTypedWindowLeaderboard creates a new sliding window leaderboard whose additional data is of type D
+func NewTypedWindowLeaderboard[D any](config WindowConfig) *WindowLeaderboard[D] {
+	config.Leaderboard.UpdatePolicy = UpdateAlways
+	config.Leaderboard.MaxMembers = 0
+	config.Leaderboard.MemberTTL = 0
+	if config.Window <= 0 {
+		config.Window = 24 * time.Hour
+	}
+
+	w := &WindowLeaderboard[D]{
+		config:      config,
+		leaderboard: NewTypedLeaderboard[D](config.Leaderboard),
+		members:     make(map[string]*windowMember[D]),
+		mutex:       sync.RWMutex{},
+		now:         time.Now,
+	}
+	w.leaderboard.now = func() time.Time {
+		return w.now()
+	}
+
+	return w
+}
+
+// Record records a score event of a member at the current time, and returns the member's standing
+// with the new aggregated score
+func (w *WindowLeaderboard[D]) Record(member string, score int64, data D) (*AddResult[D], error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	now := w.now()
+	w.expireAt(now)
+
+	state, ok := w.members[member]
+	var prev int64
+	if ok {
+		prev = w.aggregate(state)
+	} else {
+		state = &windowMember[D]{first: w.seq + 1}
+	}
+
+	// Check for overflow
+	sum := state.sum + score
+	if w.config.Aggregation == AggregateSum && ((score > 0 && sum < state.sum) || (score < 0 && sum > state.sum)) {
+		result := &AddResult[D]{Status: AddFailed, Err: ErrScoreOverflow, IsNew: !ok}
+		if current, err := w.leaderboard.GetMemberAndRank(member); err == nil {
+			result.PrevScore = current.Score
+			result.PrevRank = current.Rank
+			result.TypedRankData = *current
+		} else {
+			result.Member = member
+		}
+		return result, result.Err
+	}
+
+	w.seq++
+	event := windowEvent{seq: w.seq, member: member, score: score, at: now}
+	w.events = append(w.events, event)
+	w.members[member] = state
+
+	state.count++
+	state.sum = sum
+	state.data = data
+
+	// Drop the candidates that can no longer be the extreme, since the new event outlives them
+	if w.config.Aggregation != AggregateSum {
+		for len(state.extremes) > 0 && !w.outranks(state.extremes[len(state.extremes)-1].score, score) {
+			state.extremes = state.extremes[:len(state.extremes)-1]
+		}
+		state.extremes = append(state.extremes, event)
+	}
+
+	// An event that doesn't change the aggregated score keeps the update time of the member, so that its
+	// tie-break position is kept
+	if score := w.aggregate(state); !ok || score != prev {
+		return w.leaderboard.Add(member, score, data)
+	}
+	result := w.leaderboard.rescore(member, prev, data)
+	return &result, result.Err
+}
+
+// Remove removes a member and all its score events, errors are reported like Leaderboard.Remove
+func (w *WindowLeaderboard[D]) Remove(member string) (bool, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// The events of the member are skipped when they age out
+	delete(w.members, member)
+	return w.leaderboard.Remove(member)
+}
+
+// StartSweeper starts a background goroutine aging out score events every interval, one second if not positive,
+// so that ranks are updated even if the leaderboard is not read. The returned function stops the sweeper
+func (w *WindowLeaderboard[D]) StartSweeper(interval time.Duration) (stop func()) {
+	return startSweeper(interval, w.expire)
+}
+
+// outranks reports whether score a remains the extreme against a newer score b
+func (w *WindowLeaderboard[D]) outranks(a, b int64) bool {
+	if w.config.Aggregation == AggregateMin {
+		return a < b
+	}
+	return a > b
+}
+
+// aggregate gets the aggregated score of a member
+func (w *WindowLeaderboard[D]) aggregate(state *windowMember[D]) int64 {
+	if w.config.Aggregation == AggregateSum {
+		return state.sum
+	}
+	return state.extremes[0].score
+}
+
+// expire ages out the score events older than the window, the write lock is only taken when an event is due
+func (w *WindowLeaderboard[D]) expire() {
+	w.mutex.RLock()
+	now := w.now()
+	due := len(w.events) > 0 && !w.events[0].at.After(now.Add(-w.config.Window))
+	w.mutex.RUnlock()
+
+	if !due {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.expireAt(w.now())
+}
+
+// expireAt ages out the score events older than the window at the given time. The caller must hold the write lock
+func (w *WindowLeaderboard[D]) expireAt(now time.Time) {
+	cutoff := now.Add(-w.config.Window)
+	var touched map[string]*windowMember[D]
+
+	for len(w.events) > 0 && !w.events[0].at.After(cutoff) {
+		event := w.events[0]
+		w.events[0] = windowEvent{}
+		w.events = w.events[1:]
+
+		state, ok := w.members[event.member]
+		if !ok || event.seq < state.first {
+			continue
+		}
+
+		state.count--
+		state.sum -= event.score
+		if len(state.extremes) > 0 && state.extremes[0].seq == event.seq {
+			state.extremes[0] = windowEvent{}
+			state.extremes = state.extremes[1:]
+		}
+		if touched == nil {
+			touched = make(map[string]*windowMember[D])
+		}
+		touched[event.member] = state
+	}
+
+	// Update every member once, however many of its events aged out
+	for member, state := range touched {
+		if state.count == 0 {
+			delete(w.members, member)
+			w.leaderboard.Remove(member)
+			continue
+		}
+		w.leaderboard.rescore(member, w.aggregate(state), state.data)
+	}
+}
+
+// rescore updates the score and data of a member without changing its update time, for a score recomputed
+// without reaching a new score, so that its tie-break position is kept. The member is added like Add if it
+// doesn't exist
+func (lb *TypedLeaderboard[D]) rescore(member string, score int64, data D) AddResult[D] {
+	lb.lockWrite()
+	defer lb.mutex.Unlock()
+
+	existing := lb.skipList.GetElementByMember(member)
+	if existing == nil {
+		return lb.add(member, score, data, 0, true)
+	}
+	if lb.frozen {
+		return lb.failed(existing, member, ErrFrozen, true)
+	}
+
+	prevPosition := lb.skipList.GetRank(member, existing.Score)
+	result := AddResult[D]{
+		Status:    AddApplied,
+		PrevScore: existing.Score,
+		PrevRank:  lb.rankAt(existing, prevPosition),
+	}
+
+	memberData := existing.Data
+	memberData.Score = score
+	memberData.Data = data
+	element, err := lb.insert(memberData)
+	if err != nil {
+		return lb.failed(existing, member, err, true)
+	}
+	result.TypedMemberData = memberData
+
+	position := lb.skipList.GetRank(member, score)
+	result.Rank = lb.rankAt(element, position)
+	if position < prevPosition {
+		result.Overtaken = lb.overtaken(position, prevPosition)
+	}
+
+	return result
+}
+
+// GetRank gets a member's rank
+func (w *WindowLeaderboard[D]) GetRank(member string) (int64, error) {
+	w.expire()
+	return w.leaderboard.GetRank(member)
+}
+
+// GetMember gets a member's data
+func (w *WindowLeaderboard[D]) GetMember(member string) (*TypedMemberData[D], error) {
+	w.expire()
+	return w.leaderboard.GetMember(member)
+}
+
+// GetMemberAndRank gets a member's data and rank
+func (w *WindowLeaderboard[D]) GetMemberAndRank(member string) (*TypedRankData[D], error) {
+	w.expire()
+	return w.leaderboard.GetMemberAndRank(member)
+}
+
+// GetMembersAndRanks gets the data and rank of a batch of members
+func (w *WindowLeaderboard[D]) GetMembersAndRanks(members []string) map[string]*TypedRankData[D] {
+	w.expire()
+	return w.leaderboard.GetMembersAndRanks(members)
+}
+
+// GetRankList gets a list of rankings within a specified range
+func (w *WindowLeaderboard[D]) GetRankList(start, end int64) ([]*TypedRankData[D], error) {
+	w.expire()
+	return w.leaderboard.GetRankList(start, end)
+}
+
+// GetAroundMember gets a list of rankings around a specified member
+func (w *WindowLeaderboard[D]) GetAroundMember(member string, count int64) ([]*TypedRankData[D], error) {
+	w.expire()
+	return w.leaderboard.GetAroundMember(member, count)
+}
+
+// GetByScoreRange gets the members within a score range
+func (w *WindowLeaderboard[D]) GetByScoreRange(min, max int64, opts ScoreRangeOptions) ([]*TypedRankData[D], error) {
+	w.expire()
+	return w.leaderboard.GetByScoreRange(min, max, opts)
+}
+
+// CountByScore counts the members within a score range
+func (w *WindowLeaderboard[D]) CountByScore(min, max int64) uint64 {
+	w.expire()
+	return w.leaderboard.CountByScore(min, max)
+}
+
+// GetPercentile gets the top percentile of a member
+func (w *WindowLeaderboard[D]) GetPercentile(member string) (float64, error) {
+	w.expire()
+	return w.leaderboard.GetPercentile(member)
+}
+
+// GetMemberAtPercentile gets the last member within the top p percent
+func (w *WindowLeaderboard[D]) GetMemberAtPercentile(p float64) (*TypedRankData[D], error) {
+	w.expire()
+	return w.leaderboard.GetMemberAtPercentile(p)
+}
+
+// RankForScore gets the rank a new member with the score would occupy
+func (w *WindowLeaderboard[D]) RankForScore(score int64) int64 {
+	w.expire()
+	return w.leaderboard.RankForScore(score)
+}
+
+// RankForMemberScore gets the rank the member would occupy with the aggregated score
+func (w *WindowLeaderboard[D]) RankForMemberScore(member string, score int64) int64 {
+	w.expire()
+	return w.leaderboard.RankForMemberScore(member, score)
+}
+
+// GetTotal gets the number of members with events within the window
+func (w *WindowLeaderboard[D]) GetTotal() uint64 {
+	w.expire()
+	return w.leaderboard.GetTotal()
+}
+
+// All iterates over all members in rank order
+func (w *WindowLeaderboard[D]) All() iter.Seq2[int64, *TypedRankData[D]] {
+	return w.iterate(w.leaderboard.All())
+}
+
+// Backward iterates over all members in reverse rank order
+func (w *WindowLeaderboard[D]) Backward() iter.Seq2[int64, *TypedRankData[D]] {
+	return w.iterate(w.leaderboard.Backward())
+}
+
+// FromRank iterates over the members starting from a rank
+func (w *WindowLeaderboard[D]) FromRank(rank int64) iter.Seq2[int64, *TypedRankData[D]] {
+	return w.iterate(w.leaderboard.FromRank(rank))
+}
+
+// ScoreBetween iterates over the members within a score range
+func (w *WindowLeaderboard[D]) ScoreBetween(min, max int64) iter.Seq2[int64, *TypedRankData[D]] {
+	return w.iterate(w.leaderboard.ScoreBetween(min, max))
+}
+
+// ComputeTiers gets the members of every reward tier, like Leaderboard.ComputeTiers
+func (w *WindowLeaderboard[D]) ComputeTiers(specs []TierSpec) ([]Tier[D], error) {
+	w.expire()
+	return w.leaderboard.ComputeTiers(specs)
+}
+
+// iterate wraps an iterator of the aggregated scores, aging out score events when the iteration starts
+func (w *WindowLeaderboard[D]) iterate(seq iter.Seq2[int64, *TypedRankData[D]]) iter.Seq2[int64, *TypedRankData[D]] {
+	return func(yield func(int64, *TypedRankData[D]) bool) {
+		w.expire()
+		for rank, rankData := range seq {
+			if !yield(rank, rankData) {
+				return
+			}
+		}
+	}
+}
+
+// WindowLeaderboard implements Reader
+var _ Reader[interface{}] = (*WindowLeaderboard[interface{}])(nil)
@@ -0,0 +1,241 @@
+package rank
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWindowLeaderboardSum(t *testing.T) {
+	w := NewWindowLeaderboard(WindowConfig{
+		Leaderboard: LeaderboardConfig{
+			ID:         "window_sum",
+			Name:       "Window Sum Test",
+			ScoreOrder: true,
+		},
+		Window:      24 * time.Hour,
+		Aggregation: AggregateSum,
+	})
+
+	// Use a fake clock that only moves when the test advances it
+	now := time.Unix(1700000000, 0)
+	w.now = func() time.Time {
+		return now
+	}
+
+	w.Record("player1", 100, nil)
+	w.Record("player2", 150, nil)
+
+	now = now.Add(12 * time.Hour)
+	result, err := w.Record("player1", 100, nil)
+	if err != nil {
+		t.Fatalf("Failed to record event: %v", err)
+	}
+
+	if result.Score != 200 || result.Rank != 1 || result.PrevRank != 2 {
+		t.Errorf("Expected score 200 at rank 1 from rank 2, got %d at rank %d from rank %d", result.Score, result.Rank, result.PrevRank)
+	}
+
+	// The first events age out of the window
+	now = now.Add(12 * time.Hour)
+	if score := mustMember(t, w, "player1").Score; score != 100 {
+		t.Errorf("Expected score 100 after the first event aged out, got %d", score)
+	}
+
+	if _, err := w.GetRank("player2"); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected player2 to be removed, got %v", err)
+	}
+
+	if total := w.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member, got %d", total)
+	}
+
+	now = now.Add(12 * time.Hour)
+	count := 0
+	for range w.All() {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("Expected no members after all events aged out, got %d", count)
+	}
+
+	// Test overflow
+	w.Record("player3", math.MaxInt64, nil)
+	result, err = w.Record("player3", 1, nil)
+	if !errors.Is(err, ErrScoreOverflow) || result.Status != AddFailed || result.Score != math.MaxInt64 {
+		t.Errorf("Expected an overflow with score %d kept, got %+v, %v", int64(math.MaxInt64), result, err)
+	}
+}
+
+func TestWindowLeaderboardMaxMin(t *testing.T) {
+	tests := []struct {
+		name        string
+		aggregation Aggregation
+		scores      []int64
+	}{
+		// Events 50, 80, 60, 70 recorded one hour apart, each aging out after 3 hours
+		{"Max", AggregateMax, []int64{50, 80, 80, 80, 70, 70}},
+		{"Min", AggregateMin, []int64{50, 50, 50, 60, 60, 70}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWindowLeaderboard(WindowConfig{
+				Leaderboard: LeaderboardConfig{
+					ID:         "window_max_min",
+					Name:       "Window Max Min Test",
+					ScoreOrder: true,
+				},
+				Window:      3 * time.Hour,
+				Aggregation: tt.aggregation,
+			})
+
+			now := time.Unix(1700000000, 0)
+			w.now = func() time.Time {
+				return now
+			}
+
+			events := []int64{50, 80, 60, 70}
+			for i, score := range tt.scores {
+				if i < len(events) {
+					w.Record("player", events[i], nil)
+				}
+				if got := mustMember(t, w, "player").Score; got != score {
+					t.Errorf("Expected score %d after %d hours, got %d", score, i, got)
+				}
+				now = now.Add(time.Hour)
+			}
+		})
+	}
+}
+
+func TestWindowLeaderboardRemove(t *testing.T) {
+	w := NewWindowLeaderboard(WindowConfig{
+		Leaderboard: LeaderboardConfig{
+			ID:         "window_remove",
+			Name:       "Window Remove Test",
+			ScoreOrder: true,
+		},
+		Window:      time.Hour,
+		Aggregation: AggregateSum,
+	})
+
+	now := time.Unix(1700000000, 0)
+	w.now = func() time.Time {
+		return now
+	}
+
+	w.Record("player", 100, nil)
+	if removed, err := w.Remove("player"); err != nil || !removed {
+		t.Error("Expected player to be removed")
+	}
+
+	// The events recorded before the removal don't count anymore
+	now = now.Add(30 * time.Minute)
+	w.Record("player", 10, nil)
+
+	now = now.Add(45 * time.Minute)
+	if score := mustMember(t, w, "player").Score; score != 10 {
+		t.Errorf("Expected score 10, got %d", score)
+	}
+}
+
+// mustMember gets a member of a window leaderboard, failing the test if it doesn't exist
+func mustMember(t *testing.T, w *WindowLeaderboard[interface{}], member string) *MemberData {
+	t.Helper()
+
+	memberData, err := w.GetMember(member)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %v", member, err)
+	}
+	return memberData
+}
+
+func TestWindowLeaderboardTieBreak(t *testing.T) {
+	w := NewWindowLeaderboard(WindowConfig{
+		Leaderboard: LeaderboardConfig{
+			ScoreOrder: true,
+			TieBreak:   TieBreakEarliest,
+		},
+		Window:      time.Hour,
+		Aggregation: AggregateSum,
+	})
+
+	start := time.Unix(1700000000, 0)
+	now := start
+	w.now = func() time.Time {
+		return now
+	}
+
+	w.Record("a", 3, nil)
+	now = start.Add(10 * time.Minute)
+	w.Record("a", 5, nil)
+	now = start.Add(20 * time.Minute)
+	w.Record("b", 5, nil)
+
+	// a's first event ages out, which is not an activity of a, so a keeps its update time and stays ahead of b
+	now = start.Add(time.Hour)
+	member, err := w.GetMemberAndRank("a")
+	if err != nil || member.Score != 5 || member.Rank != 1 {
+		t.Errorf("Expected a with 5 at rank 1, got %v %v", member, err)
+	}
+	if !member.UpdatedAt.Equal(start.Add(10 * time.Minute)) {
+		t.Errorf("Expected a to keep its update time %v, got %v", start.Add(10*time.Minute), member.UpdatedAt)
+	}
+}
+
+func TestWindowLeaderboardUnchangedAggregate(t *testing.T) {
+	w := NewWindowLeaderboard(WindowConfig{
+		Leaderboard: LeaderboardConfig{
+			ScoreOrder: true,
+			TieBreak:   TieBreakEarliest,
+		},
+		Window:      time.Hour,
+		Aggregation: AggregateMax,
+	})
+
+	start := time.Unix(1700000000, 0)
+	now := start
+	w.now = func() time.Time {
+		return now
+	}
+
+	w.Record("a", 100, nil)
+	now = start.Add(time.Minute)
+	w.Record("b", 100, nil)
+
+	// An event below the max doesn't change a's score, so a keeps its update time and stays ahead of b
+	now = start.Add(2 * time.Minute)
+	result, err := w.Record("a", 5, "latest")
+	if err != nil || result.Score != 100 || result.Rank != 1 || result.PrevRank != 1 || result.Data != "latest" {
+		t.Errorf("Expected a with 100 at rank 1 and its latest data, got %+v %v", result, err)
+	}
+	if rank, _ := w.GetRank("b"); rank != 2 {
+		t.Errorf("Expected b at rank 2, got %d", rank)
+	}
+	if member := mustMember(t, w, "a"); !member.UpdatedAt.Equal(start) {
+		t.Errorf("Expected a to keep its update time %v, got %v", start, member.UpdatedAt)
+	}
+}
+
+func TestWindowLeaderboardDefaultWindow(t *testing.T) {
+	w := NewWindowLeaderboard(WindowConfig{Leaderboard: LeaderboardConfig{ScoreOrder: true}})
+
+	start := time.Unix(1700000000, 0)
+	now := start
+	w.now = func() time.Time {
+		return now
+	}
+
+	// A window that is not positive is one day long
+	w.Record("a", 1, nil)
+	now = start.Add(23 * time.Hour)
+	if total := w.GetTotal(); total != 1 {
+		t.Errorf("Expected 1 member within the default window, got %d", total)
+	}
+	now = start.Add(24 * time.Hour)
+	if total := w.GetTotal(); total != 0 {
+		t.Errorf("Expected the member to age out after a day, got %d members", total)
+	}
+}